@@ -0,0 +1,268 @@
+package main
+
+import (
+	"country"
+	"encoding/json"
+	"io/ioutil"
+	"logger"
+	"sort"
+	"strings"
+	"weekly"
+)
+
+// Names at least this similar are reported as a rename rather than a
+// removal and an addition, where 1 is identical
+const renameSimilarity = 0.5
+
+var weeklyJsonRoot = ""
+var administrativeDivisionHistoryFile = ""
+
+type division struct {
+	name           string
+	normalisedType string
+}
+
+// divisionChange is a division added, removed or renamed between weeks
+type divisionChange struct {
+	week         string
+	gec          string
+	country      string
+	change       string
+	divisionType string
+	name         string
+	previousName string
+}
+
+// Creates a csv of the changes to the administrative divisions of every
+// country between weekly files.
+// A removal and an addition of the same type in the same week are reported
+// as a probable rename if the names are similar, or if they are the only
+// change of that type.
+func main() {
+	// read config
+	configBytes, err := ioutil.ReadFile("config.json")
+	if err != nil {
+		logger.Stderr("Error reading config.json")
+		logger.Stderr(err)
+		return
+	}
+	// parse config
+	config := map[string]string{}
+	err = json.Unmarshal(configBytes, &config)
+	var exists bool
+	weeklyJsonRoot, exists = config["weekly_json_root"]
+	if !exists {
+		logger.Stderr("Missing config value: weekly_json_root")
+		return
+	}
+	administrativeDivisionHistoryFile, exists = config["administrative_division_history_file"]
+	if !exists {
+		logger.Stderr("Missing config value: administrative_division_history_file")
+		return
+	}
+	files, err := weekly.Files(weeklyJsonRoot)
+	if err != nil {
+		logger.Stderr("Error reading weekly_json_root")
+		logger.Stderr(err)
+		return
+	}
+	changes := []divisionChange{}
+	previousForGec := map[string][]division{}
+	for _, f := range files {
+		dateStr := f.Date.Format("2006-01-02")
+		logger.Stdout("Comparing administrative divisions for", dateStr)
+		o, err := weekly.Load(f.Filelocation)
+		if err != nil {
+			logger.Stderr("Error loading weekly file", f.Filelocation)
+			logger.Stderr(err)
+			continue
+		}
+		countries, err := weekly.Countries(o)
+		if err != nil {
+			logger.Stderr("Error getting countries from weekly file", f.Filelocation)
+			logger.Stderr(err)
+			continue
+		}
+		for _, c := range countries {
+			list := weekly.ListAtPath(c.Json, "data", "government", "administrative_divisions")
+			if len(list) == 0 {
+				continue
+			}
+			gec := c.Gec
+			if gec == "" {
+				gec = c.Key
+			}
+			divisions := divisionsFromList(list)
+			previous, hasPrevious := previousForGec[gec]
+			previousForGec[gec] = divisions
+			if !hasPrevious {
+				continue
+			}
+			for _, change := range compareDivisions(previous, divisions) {
+				change.week = dateStr
+				change.gec = gec
+				change.country = c.Name
+				changes = append(changes, change)
+			}
+		}
+	}
+	err = writeChanges(administrativeDivisionHistoryFile, changes)
+	if err != nil {
+		logger.Stderr("Error saving administrative_division_history_file")
+		logger.Stderr(err)
+		return
+	}
+	logger.Stdout("Complete,", len(changes), "changes")
+}
+
+// Weekly files parsed before the normalised type was added are normalised
+// from the type
+func divisionsFromList(list []interface{}) []division {
+	divisions := []division{}
+	for _, item := range list {
+		name, _ := weekly.ValueAtPath(item, "name")
+		nameStr, _ := name.(string)
+		nameStr = strings.TrimSpace(nameStr)
+		if nameStr == "" {
+			continue
+		}
+		normalised, exists := weekly.ValueAtPath(item, "normalised_type")
+		normalisedStr, _ := normalised.(string)
+		if !exists {
+			t, _ := weekly.ValueAtPath(item, "type")
+			tStr, _ := t.(string)
+			normalisedStr = country.NormaliseDivisionType(tStr)
+		}
+		divisions = append(divisions, division{nameStr, normalisedStr})
+	}
+	return divisions
+}
+
+// Returns the additions, removals and probable renames from previous to
+// current
+func compareDivisions(previous, current []division) []divisionChange {
+	changes := []divisionChange{}
+	previousNames := map[string]bool{}
+	for _, d := range previous {
+		previousNames[d.name] = true
+	}
+	currentNames := map[string]bool{}
+	for _, d := range current {
+		currentNames[d.name] = true
+	}
+	removedByType := map[string][]division{}
+	addedByType := map[string][]division{}
+	types := []string{}
+	seenType := map[string]bool{}
+	addType := func(t string) {
+		if !seenType[t] {
+			seenType[t] = true
+			types = append(types, t)
+		}
+	}
+	for _, d := range previous {
+		if !currentNames[d.name] {
+			removedByType[d.normalisedType] = append(removedByType[d.normalisedType], d)
+			addType(d.normalisedType)
+		}
+	}
+	for _, d := range current {
+		if !previousNames[d.name] {
+			addedByType[d.normalisedType] = append(addedByType[d.normalisedType], d)
+			addType(d.normalisedType)
+		}
+	}
+	for _, t := range types {
+		removed := removedByType[t]
+		added := addedByType[t]
+		onlyChange := len(removed) == 1 && len(added) == 1
+		renamedFrom := map[string]string{}
+		usedRemoved := map[string]bool{}
+		for _, a := range added {
+			best := ""
+			bestSimilarity := 0.0
+			for _, r := range removed {
+				if usedRemoved[r.name] {
+					continue
+				}
+				s := similarity(a.name, r.name)
+				if s > bestSimilarity {
+					best = r.name
+					bestSimilarity = s
+				}
+			}
+			if best != "" && (bestSimilarity >= renameSimilarity || onlyChange) {
+				renamedFrom[a.name] = best
+				usedRemoved[best] = true
+			}
+		}
+		for _, r := range removed {
+			if !usedRemoved[r.name] {
+				changes = append(changes, divisionChange{change: "removed", divisionType: t, name: r.name})
+			}
+		}
+		for _, a := range added {
+			previousName, isRename := renamedFrom[a.name]
+			if isRename {
+				changes = append(changes, divisionChange{change: "renamed", divisionType: t, name: a.name, previousName: previousName})
+			} else {
+				changes = append(changes, divisionChange{change: "added", divisionType: t, name: a.name})
+			}
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].name < changes[j].name
+	})
+	return changes
+}
+
+// Returns the similarity of two names from 0 to 1 using the edit distance
+// of the lowercase names, so spelling changes such as
+// Dnipropetrovs'k to Dnipropetrovsk are similar
+func similarity(a, b string) float64 {
+	ar := []rune(strings.ToLower(a))
+	br := []rune(strings.ToLower(b))
+	maxLength := len(ar)
+	if len(br) > maxLength {
+		maxLength = len(br)
+	}
+	if maxLength == 0 {
+		return 1
+	}
+	// levenshtein distance
+	row := make([]int, len(br)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		diagonal := row[0]
+		row[0] = i
+		for j := 1; j <= len(br); j++ {
+			above := row[j]
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			row[j] = minInt(minInt(row[j]+1, row[j-1]+1), diagonal+cost)
+			diagonal = above
+		}
+	}
+	return 1 - float64(row[len(br)])/float64(maxLength)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func writeChanges(filelocation string, changes []divisionChange) error {
+	rows := [][]string{
+		[]string{"week", "gec", "country", "change", "type", "name", "previous_name"},
+	}
+	for _, c := range changes {
+		rows = append(rows, []string{c.week, c.gec, c.country, c.change, c.divisionType, c.name, c.previousName})
+	}
+	return weekly.WriteCsv(filelocation, rows)
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"logger"
+	"orderedmap"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"weekly"
+)
+
+// Sizes of the svg in pixels
+const pyramidWidth = 600
+const pyramidBarHeight = 24
+const pyramidLabelWidth = 80
+const pyramidMargin = 40
+
+// Keys used for age brackets in weekly files created before the pyramid was
+// added, eg 15_to_24 and 65_and_over
+var bracketKeyRe = regexp.MustCompile(`^([0-9]+)_(?:to_([0-9]+)|and_over)$`)
+
+var weeklyJsonRoot = ""
+var agePyramidFile = ""
+
+type pyramidBar struct {
+	label   string
+	minAge  int
+	males   float64
+	females float64
+	// the percent of the population when the counts by sex are missing
+	percent   float64
+	isPercent bool
+}
+
+// Creates an svg population pyramid for age_pyramid_country, which may be
+// the country key, GEC code or name, using the latest weekly file on or
+// before age_pyramid_date. Bars are the number of males and females, or a
+// single bar of the percent of the population when any bracket is missing
+// the counts, since the percent is not split by sex.
+func main() {
+	// read config
+	configBytes, err := ioutil.ReadFile("config.json")
+	if err != nil {
+		logger.Stderr("Error reading config.json")
+		logger.Stderr(err)
+		return
+	}
+	// parse config
+	config := map[string]string{}
+	err = json.Unmarshal(configBytes, &config)
+	var exists bool
+	weeklyJsonRoot, exists = config["weekly_json_root"]
+	if !exists {
+		logger.Stderr("Missing config value: weekly_json_root")
+		return
+	}
+	agePyramidFile, exists = config["age_pyramid_file"]
+	if !exists {
+		logger.Stderr("Missing config value: age_pyramid_file")
+		return
+	}
+	countryStr, exists := config["age_pyramid_country"]
+	if !exists {
+		logger.Stderr("Missing config value: age_pyramid_country")
+		return
+	}
+	date := time.Now()
+	dateStr, exists := config["age_pyramid_date"]
+	if exists {
+		date, err = time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			logger.Stderr("Invalid config value: age_pyramid_date")
+			return
+		}
+	}
+	// find the weekly file
+	files, err := weekly.Files(weeklyJsonRoot)
+	if err != nil {
+		logger.Stderr("Error reading weekly_json_root")
+		logger.Stderr(err)
+		return
+	}
+	var f *weekly.File
+	for i := range files {
+		if !files[i].Date.After(date) {
+			f = &files[i]
+		}
+	}
+	if f == nil {
+		logger.Stderr("No weekly file on or before", date.Format("2006-01-02"))
+		return
+	}
+	o, err := weekly.Load(f.Filelocation)
+	if err != nil {
+		logger.Stderr("Error loading weekly file", f.Filelocation)
+		logger.Stderr(err)
+		return
+	}
+	countries, err := weekly.Countries(o)
+	if err != nil {
+		logger.Stderr("Error getting countries from weekly file", f.Filelocation)
+		logger.Stderr(err)
+		return
+	}
+	for _, c := range countries {
+		isCountry := c.Key == countryStr || c.Gec == countryStr
+		isCountry = isCountry || strings.ToLower(c.Name) == strings.ToLower(countryStr)
+		if !isCountry {
+			continue
+		}
+		ageStructure, _ := weekly.ValueAtPath(c.Json, "data", "people", "age_structure")
+		bars := pyramidBars(ageStructure)
+		if len(bars) == 0 {
+			logger.Stderr("No age structure for", c.Name, "on", f.Date.Format("2006-01-02"))
+			return
+		}
+		ageDate, _ := weekly.ValueAtPath(ageStructure, "date")
+		ageDateStr, _ := ageDate.(string)
+		title := c.Name + " age structure"
+		if ageDateStr != "" {
+			title = title + " (" + ageDateStr + ")"
+		}
+		err = ioutil.WriteFile(agePyramidFile, pyramidSvg(title, bars), 0664)
+		if err != nil {
+			logger.Stderr("Error saving age_pyramid_file")
+			logger.Stderr(err)
+			return
+		}
+		logger.Stdout("Complete,", title)
+		return
+	}
+	logger.Stderr("Country not found:", countryStr)
+}
+
+// Returns the age brackets from the youngest to the oldest. Weekly files
+// created before the pyramid was added only have the brackets as keys.
+func ageBrackets(ageStructure interface{}) []interface{} {
+	pyramid := weekly.ListAtPath(ageStructure, "pyramid")
+	if len(pyramid) > 0 {
+		return pyramid
+	}
+	keys := []string{}
+	switch o := ageStructure.(type) {
+	case *orderedmap.OrderedMap:
+		keys = o.Keys()
+	case orderedmap.OrderedMap:
+		keys = o.Keys()
+	}
+	brackets := []interface{}{}
+	for _, key := range keys {
+		m := bracketKeyRe.FindStringSubmatch(key)
+		if m == nil {
+			continue
+		}
+		bracket, _ := weekly.ValueAtPath(ageStructure, key)
+		b := orderedmap.New()
+		minAge, _ := strconv.Atoi(m[1])
+		b.Set("min_age", float64(minAge))
+		if m[2] != "" {
+			maxAge, _ := strconv.Atoi(m[2])
+			b.Set("max_age", float64(maxAge))
+		}
+		for _, field := range []string{"percent", "males", "females"} {
+			v, exists := weekly.ValueAtPath(bracket, field)
+			if exists {
+				b.Set(field, v)
+			}
+		}
+		brackets = append(brackets, b)
+	}
+	sort.SliceStable(brackets, func(i, j int) bool {
+		minAgeI, _ := weekly.ValueAtPath(brackets[i], "min_age")
+		minAgeJ, _ := weekly.ValueAtPath(brackets[j], "min_age")
+		return minAgeI.(float64) < minAgeJ.(float64)
+	})
+	return brackets
+}
+
+// Uses percent for every bar unless every bracket has both counts, so the
+// bars are on the same scale
+func pyramidBars(ageStructure interface{}) []pyramidBar {
+	brackets := ageBrackets(ageStructure)
+	usePercent := false
+	for _, bracket := range brackets {
+		_, hasMales := weekly.ValueAtPath(bracket, "males")
+		_, hasFemales := weekly.ValueAtPath(bracket, "females")
+		usePercent = usePercent || !hasMales || !hasFemales
+	}
+	bars := []pyramidBar{}
+	for _, bracket := range brackets {
+		b := pyramidBar{isPercent: usePercent}
+		minAge, _ := weekly.ValueAtPath(bracket, "min_age")
+		minAgeFloat, _ := minAge.(float64)
+		b.minAge = int(minAgeFloat)
+		b.label = strconv.Itoa(b.minAge) + "+"
+		maxAge, hasMaxAge := weekly.ValueAtPath(bracket, "max_age")
+		if maxAgeFloat, isFloat := maxAge.(float64); hasMaxAge && isFloat {
+			b.label = strconv.Itoa(b.minAge) + "-" + strconv.Itoa(int(maxAgeFloat))
+		}
+		if usePercent {
+			percent, _ := weekly.ValueAtPath(bracket, "percent")
+			percentFloat, isFloat := percent.(float64)
+			if !isFloat {
+				continue
+			}
+			b.percent = percentFloat
+		} else {
+			males, _ := weekly.ValueAtPath(bracket, "males")
+			females, _ := weekly.ValueAtPath(bracket, "females")
+			b.males, _ = males.(float64)
+			b.females, _ = females.(float64)
+		}
+		bars = append(bars, b)
+	}
+	return bars
+}
+
+func svgText(x, y int, anchor, text string) string {
+	var escaped bytes.Buffer
+	xml.EscapeText(&escaped, []byte(text))
+	return fmt.Sprintf(`<text x="%d" y="%d" text-anchor="%s">%s</text>`, x, y, anchor, escaped.String()) + "\n"
+}
+
+// Draws the youngest bracket at the bottom with males on the left and
+// females on the right, both scaled to the largest bar
+func pyramidSvg(title string, bars []pyramidBar) []byte {
+	if len(bars) > 0 && bars[0].isPercent {
+		return percentChartSvg(title, bars)
+	}
+	largest := 0.0
+	for _, b := range bars {
+		if b.males > largest {
+			largest = b.males
+		}
+		if b.females > largest {
+			largest = b.females
+		}
+	}
+	if largest == 0 {
+		largest = 1
+	}
+	height := pyramidMargin*2 + len(bars)*pyramidBarHeight
+	centre := pyramidWidth / 2
+	maxBarWidth := float64(centre - pyramidLabelWidth/2 - pyramidMargin)
+	var svg bytes.Buffer
+	svg.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12">`, pyramidWidth, height) + "\n")
+	svg.WriteString(svgText(centre, pyramidMargin/2, "middle", title))
+	svg.WriteString(svgText(pyramidMargin, pyramidMargin-6, "start", "Male"))
+	svg.WriteString(svgText(pyramidWidth-pyramidMargin, pyramidMargin-6, "end", "Female"))
+	for i, b := range bars {
+		y := pyramidMargin + (len(bars)-1-i)*pyramidBarHeight
+		malesWidth := int(b.males / largest * maxBarWidth)
+		femalesWidth := int(b.females / largest * maxBarWidth)
+		svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="#4a7ebb"/>`,
+			centre-pyramidLabelWidth/2-malesWidth, y+2, malesWidth, pyramidBarHeight-4) + "\n")
+		svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="#c0504d"/>`,
+			centre+pyramidLabelWidth/2, y+2, femalesWidth, pyramidBarHeight-4) + "\n")
+		svg.WriteString(svgText(centre, y+pyramidBarHeight/2+4, "middle", b.label))
+	}
+	svg.WriteString("</svg>\n")
+	return svg.Bytes()
+}
+
+// Draws the youngest bracket at the bottom with one bar for the percent of
+// the population in each bracket, scaled to the largest bar. Used when the
+// counts by sex are missing.
+func percentChartSvg(title string, bars []pyramidBar) []byte {
+	largest := 0.0
+	for _, b := range bars {
+		if b.percent > largest {
+			largest = b.percent
+		}
+	}
+	if largest == 0 {
+		largest = 1
+	}
+	height := pyramidMargin*2 + len(bars)*pyramidBarHeight
+	barX := pyramidMargin + pyramidLabelWidth
+	maxBarWidth := float64(pyramidWidth - barX - pyramidMargin)
+	var svg bytes.Buffer
+	svg.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12">`, pyramidWidth, height) + "\n")
+	svg.WriteString(svgText(pyramidWidth/2, pyramidMargin/2, "middle", title))
+	svg.WriteString(svgText(barX, pyramidMargin-6, "start", "% of population, not split by sex"))
+	for i, b := range bars {
+		y := pyramidMargin + (len(bars)-1-i)*pyramidBarHeight
+		width := int(b.percent / largest * maxBarWidth)
+		svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="#7f7f7f"/>`,
+			barX, y+2, width, pyramidBarHeight-4) + "\n")
+		svg.WriteString(svgText(barX-8, y+pyramidBarHeight/2+4, "end", b.label))
+		svg.WriteString(svgText(barX+width+4, y+pyramidBarHeight/2+4, "start", strconv.FormatFloat(b.percent, 'f', -1, 64)+"%"))
+	}
+	svg.WriteString("</svg>\n")
+	return svg.Bytes()
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"country"
+	"encoding/json"
+	"io/ioutil"
+	"logger"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"weekly"
+)
+
+var weeklyJsonRoot = ""
+var aviationComparisonRoot = ""
+
+// runwayCounts is the number of paved and unpaved airports with runways of
+// one length
+type runwayCounts struct {
+	length  country.RunwayLength
+	paved   int
+	unpaved int
+}
+
+// Creates a csv of the number of airports and heliports for every country in
+// every week and a csv of the airports by runway length, so aviation
+// infrastructure can be compared across countries and dates.
+func main() {
+	// read config
+	configBytes, err := ioutil.ReadFile("config.json")
+	if err != nil {
+		logger.Stderr("Error reading config.json")
+		logger.Stderr(err)
+		return
+	}
+	// parse config
+	config := map[string]string{}
+	err = json.Unmarshal(configBytes, &config)
+	var exists bool
+	weeklyJsonRoot, exists = config["weekly_json_root"]
+	if !exists {
+		logger.Stderr("Missing config value: weekly_json_root")
+		return
+	}
+	aviationComparisonRoot, exists = config["aviation_comparison_root"]
+	if !exists {
+		logger.Stderr("Missing config value: aviation_comparison_root")
+		return
+	}
+	err = os.MkdirAll(aviationComparisonRoot, 0777)
+	if err != nil {
+		logger.Stderr("Error creating aviation_comparison_root")
+		logger.Stderr(err)
+		return
+	}
+	files, err := weekly.Files(weeklyJsonRoot)
+	if err != nil {
+		logger.Stderr("Error reading weekly_json_root")
+		logger.Stderr(err)
+		return
+	}
+	airportRows := [][]string{
+		[]string{"week", "gec", "country", "airports", "paved", "unpaved", "heliports"},
+	}
+	runwayRows := [][]string{
+		[]string{"week", "gec", "country", "runway_length", "min_metres", "max_metres", "paved", "unpaved", "total"},
+	}
+	for _, f := range files {
+		dateStr := f.Date.Format("2006-01-02")
+		logger.Stdout("Comparing aviation for", dateStr)
+		o, err := weekly.Load(f.Filelocation)
+		if err != nil {
+			logger.Stderr("Error loading weekly file", f.Filelocation)
+			logger.Stderr(err)
+			continue
+		}
+		countries, err := weekly.Countries(o)
+		if err != nil {
+			logger.Stderr("Error getting countries from weekly file", f.Filelocation)
+			logger.Stderr(err)
+			continue
+		}
+		for _, c := range countries {
+			airTransport, exists := weekly.ValueAtPath(c.Json, "data", "transportation", "air_transport")
+			if !exists {
+				continue
+			}
+			gec := c.Gec
+			if gec == "" {
+				gec = c.Key
+			}
+			airports, _ := weekly.ValueAtPath(airTransport, "airports", "total", "airports")
+			paved, _ := weekly.ValueAtPath(airTransport, "airports", "paved", "total")
+			unpaved, _ := weekly.ValueAtPath(airTransport, "airports", "unpaved", "total")
+			heliports, _ := weekly.ValueAtPath(airTransport, "heliports", "total")
+			airportRows = append(airportRows, []string{
+				dateStr,
+				gec,
+				c.Name,
+				weekly.NumberString(airports),
+				weekly.NumberString(paved),
+				weekly.NumberString(unpaved),
+				weekly.NumberString(heliports),
+			})
+			for _, r := range runwaysByLength(airTransport) {
+				maxMetres := ""
+				if r.length.HasMax {
+					maxMetres = strconv.Itoa(r.length.MaxMetres)
+				}
+				runwayRows = append(runwayRows, []string{
+					dateStr,
+					gec,
+					c.Name,
+					r.length.Key(),
+					strconv.Itoa(r.length.MinMetres),
+					maxMetres,
+					strconv.Itoa(r.paved),
+					strconv.Itoa(r.unpaved),
+					strconv.Itoa(r.paved + r.unpaved),
+				})
+			}
+		}
+	}
+	err = weekly.WriteCsv(path.Join(aviationComparisonRoot, "airports.csv"), airportRows)
+	if err != nil {
+		logger.Stderr("Error saving airports.csv")
+		logger.Stderr(err)
+	}
+	err = weekly.WriteCsv(path.Join(aviationComparisonRoot, "runways.csv"), runwayRows)
+	if err != nil {
+		logger.Stderr("Error saving runways.csv")
+		logger.Stderr(err)
+	}
+	logger.Stdout("Complete,", len(airportRows)-1, "rows")
+}
+
+// Returns the paved and unpaved counts for each runway length, longest
+// first, from the by_runway_length table of the parser. Weekly files parsed
+// before the runway lengths were typed have a key for each length instead,
+// eg over_3_047_metres.
+func runwaysByLength(airTransport interface{}) []runwayCounts {
+	list := []runwayCounts{}
+	for _, row := range weekly.ListAtPath(airTransport, "airports", "by_runway_length") {
+		minMetres, _ := weekly.ValueAtPath(row, "min_metres")
+		maxMetres, hasMax := weekly.ValueAtPath(row, "max_metres")
+		paved, _ := weekly.ValueAtPath(row, "paved")
+		unpaved, _ := weekly.ValueAtPath(row, "unpaved")
+		l := country.RunwayLength{HasMax: hasMax}
+		l.MinMetres = int(weekly.FloatValue(minMetres))
+		l.MaxMetres = int(weekly.FloatValue(maxMetres))
+		list = append(list, runwayCounts{
+			length:  l,
+			paved:   int(weekly.FloatValue(paved)),
+			unpaved: int(weekly.FloatValue(unpaved)),
+		})
+	}
+	if len(list) > 0 {
+		return list
+	}
+	return legacyRunwaysByLength(airTransport)
+}
+
+// Returns the counts for each runway length from the keys for each length in
+// weekly files parsed before the runway lengths were typed
+func legacyRunwaysByLength(airTransport interface{}) []runwayCounts {
+	counts := map[string]*runwayCounts{}
+	for _, surface := range []string{"paved", "unpaved"} {
+		runways, exists := weekly.ValueAtPath(airTransport, "airports", surface)
+		if !exists {
+			continue
+		}
+		for _, key := range weekly.MapKeys(runways) {
+			l, err := country.StringToRunwayLength(key)
+			if err != nil {
+				continue
+			}
+			value, _ := weekly.ValueAtPath(runways, key)
+			count := int(weekly.FloatValue(value))
+			r, exists := counts[l.Key()]
+			if !exists {
+				r = &runwayCounts{length: l}
+				counts[l.Key()] = r
+			}
+			if surface == "paved" {
+				r.paved = count
+			} else {
+				r.unpaved = count
+			}
+		}
+	}
+	list := []runwayCounts{}
+	for _, r := range counts {
+		list = append(list, *r)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].length.MinMetres != list[j].length.MinMetres {
+			return list[i].length.MinMetres > list[j].length.MinMetres
+		}
+		return list[i].length.MaxMetres > list[j].length.MaxMetres
+	})
+	return list
+}
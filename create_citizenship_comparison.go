@@ -0,0 +1,162 @@
+package main
+
+import (
+	"country"
+	"encoding/json"
+	"io/ioutil"
+	"logger"
+	"os"
+	"path"
+	"strconv"
+	"weekly"
+)
+
+var weeklyJsonRoot = ""
+var citizenshipComparisonRoot = ""
+
+// The fields compared between weeks, in the order of the csv columns
+var citizenshipFields = []string{
+	"citizenship_by_birth",
+	"citizenship_by_descent_only",
+	"dual_citizenship_recognized",
+	"dual_citizenship_exception",
+	"residency_years",
+}
+
+// Creates a csv of the citizenship rules for every country in every week and
+// a csv of every change to those rules between weeks, eg a country which
+// starts recognizing dual citizenship.
+func main() {
+	// read config
+	configBytes, err := ioutil.ReadFile("config.json")
+	if err != nil {
+		logger.Stderr("Error reading config.json")
+		logger.Stderr(err)
+		return
+	}
+	// parse config
+	config := map[string]string{}
+	err = json.Unmarshal(configBytes, &config)
+	var exists bool
+	weeklyJsonRoot, exists = config["weekly_json_root"]
+	if !exists {
+		logger.Stderr("Missing config value: weekly_json_root")
+		return
+	}
+	citizenshipComparisonRoot, exists = config["citizenship_comparison_root"]
+	if !exists {
+		logger.Stderr("Missing config value: citizenship_comparison_root")
+		return
+	}
+	err = os.MkdirAll(citizenshipComparisonRoot, 0777)
+	if err != nil {
+		logger.Stderr("Error creating citizenship_comparison_root")
+		logger.Stderr(err)
+		return
+	}
+	files, err := weekly.Files(weeklyJsonRoot)
+	if err != nil {
+		logger.Stderr("Error reading weekly_json_root")
+		logger.Stderr(err)
+		return
+	}
+	rows := [][]string{
+		append([]string{"week", "gec", "country"}, citizenshipFields...),
+	}
+	changes := [][]string{
+		[]string{"week", "gec", "country", "field", "previous", "current"},
+	}
+	previousForGec := map[string]map[string]string{}
+	for _, f := range files {
+		dateStr := f.Date.Format("2006-01-02")
+		logger.Stdout("Comparing citizenship for", dateStr)
+		o, err := weekly.Load(f.Filelocation)
+		if err != nil {
+			logger.Stderr("Error loading weekly file", f.Filelocation)
+			logger.Stderr(err)
+			continue
+		}
+		countries, err := weekly.Countries(o)
+		if err != nil {
+			logger.Stderr("Error getting countries from weekly file", f.Filelocation)
+			logger.Stderr(err)
+			continue
+		}
+		for _, c := range countries {
+			citizenship, exists := weekly.ValueAtPath(c.Json, "data", "government", "citizenship")
+			if !exists {
+				continue
+			}
+			gec := c.Gec
+			if gec == "" {
+				gec = c.Key
+			}
+			values := citizenshipValues(citizenship)
+			row := []string{dateStr, gec, c.Name}
+			for _, field := range citizenshipFields {
+				row = append(row, values[field])
+			}
+			rows = append(rows, row)
+			previous, hasPrevious := previousForGec[gec]
+			previousForGec[gec] = values
+			if !hasPrevious {
+				continue
+			}
+			for _, field := range citizenshipFields {
+				if previous[field] != values[field] {
+					changes = append(changes, []string{dateStr, gec, c.Name, field, previous[field], values[field]})
+				}
+			}
+		}
+	}
+	err = weekly.WriteCsv(path.Join(citizenshipComparisonRoot, "citizenship.csv"), rows)
+	if err != nil {
+		logger.Stderr("Error saving citizenship.csv")
+		logger.Stderr(err)
+	}
+	err = weekly.WriteCsv(path.Join(citizenshipComparisonRoot, "citizenship_changes.csv"), changes)
+	if err != nil {
+		logger.Stderr("Error saving citizenship_changes.csv")
+		logger.Stderr(err)
+	}
+	logger.Stdout("Complete,", len(changes)-1, "changes")
+}
+
+// Returns the compared fields as strings.
+// Weekly files parsed before the citizenship values were typed contain the
+// original strings, which are converted the same way as the parser.
+func citizenshipValues(citizenship interface{}) map[string]string {
+	values := map[string]string{}
+	for _, key := range []string{"citizenship_by_birth", "dual_citizenship_recognized"} {
+		v, exists := weekly.ValueAtPath(citizenship, key)
+		if !exists {
+			continue
+		}
+		value, _ := weekly.ValueAtPath(v, "value")
+		exception, _ := weekly.ValueAtPath(v, "exception")
+		if s, isString := v.(string); isString {
+			if b, e, hasValue := country.YesNo(s); hasValue {
+				value, exception = b, e
+			}
+		}
+		if b, isBool := value.(bool); isBool {
+			values[key] = strconv.FormatBool(b)
+		}
+		if key == "dual_citizenship_recognized" {
+			values["dual_citizenship_exception"], _ = exception.(string)
+		}
+	}
+	descent, _ := weekly.ValueAtPath(citizenship, "citizenship_by_descent_only")
+	values["citizenship_by_descent_only"], _ = descent.(string)
+	residency, _ := weekly.ValueAtPath(citizenship, "residency_requirement_for_naturalization")
+	years, _ := weekly.ValueAtPath(residency, "years")
+	if s, isString := residency.(string); isString {
+		if y, hasYears := country.ResidencyYears(s); hasYears {
+			years = y
+		}
+	}
+	if y, isNumber := years.(float64); isNumber {
+		values["residency_years"] = strconv.FormatFloat(y, 'f', -1, 64)
+	}
+	return values
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"logger"
+	"sort"
+	"weekly"
+)
+
+var weeklyJsonRoot = ""
+var constitutionalTimelineFile = ""
+
+// timelineRow is a single event for a country along with the first and last
+// week it appears in the weekly files
+type timelineRow struct {
+	gec         string
+	country     string
+	date        string
+	event       string
+	source      string
+	description string
+	firstSeen   string
+	lastSeen    string
+}
+
+// Creates a csv of every constitutional event for every country across all
+// the weekly files, sorted by country then date.
+// Events which are reworded between weeks are listed once for each wording.
+func main() {
+	// read config
+	configBytes, err := ioutil.ReadFile("config.json")
+	if err != nil {
+		logger.Stderr("Error reading config.json")
+		logger.Stderr(err)
+		return
+	}
+	// parse config
+	config := map[string]string{}
+	err = json.Unmarshal(configBytes, &config)
+	var exists bool
+	weeklyJsonRoot, exists = config["weekly_json_root"]
+	if !exists {
+		logger.Stderr("Missing config value: weekly_json_root")
+		return
+	}
+	constitutionalTimelineFile, exists = config["constitutional_timeline_file"]
+	if !exists {
+		logger.Stderr("Missing config value: constitutional_timeline_file")
+		return
+	}
+	files, err := weekly.Files(weeklyJsonRoot)
+	if err != nil {
+		logger.Stderr("Error reading weekly_json_root")
+		logger.Stderr(err)
+		return
+	}
+	rows := []*timelineRow{}
+	rowForKey := map[string]*timelineRow{}
+	for _, f := range files {
+		dateStr := f.Date.Format("2006-01-02")
+		logger.Stdout("Reading timeline for", dateStr)
+		o, err := weekly.Load(f.Filelocation)
+		if err != nil {
+			logger.Stderr("Error loading weekly file", f.Filelocation)
+			logger.Stderr(err)
+			continue
+		}
+		countries, err := weekly.Countries(o)
+		if err != nil {
+			logger.Stderr("Error getting countries from weekly file", f.Filelocation)
+			logger.Stderr(err)
+			continue
+		}
+		for _, c := range countries {
+			gec := c.Gec
+			if gec == "" {
+				gec = c.Key
+			}
+			events := weekly.ListAtPath(c.Json, "data", "government", "constitutional_timeline")
+			for _, e := range events {
+				r := timelineRow{
+					gec:       gec,
+					country:   c.Name,
+					firstSeen: dateStr,
+					lastSeen:  dateStr,
+				}
+				r.date = weekly.StringAtPath(e, "date")
+				r.event = weekly.StringAtPath(e, "event")
+				r.source = weekly.StringAtPath(e, "source")
+				r.description = weekly.StringAtPath(e, "description")
+				key := r.gec + "|" + r.date + "|" + r.event + "|" + r.source + "|" + r.description
+				existing, exists := rowForKey[key]
+				if exists {
+					existing.lastSeen = dateStr
+					existing.country = c.Name
+					continue
+				}
+				rowForKey[key] = &r
+				rows = append(rows, &r)
+			}
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].gec != rows[j].gec {
+			return rows[i].gec < rows[j].gec
+		}
+		return rows[i].date < rows[j].date
+	})
+	err = writeTimeline(constitutionalTimelineFile, rows)
+	if err != nil {
+		logger.Stderr("Error saving constitutional_timeline_file")
+		logger.Stderr(err)
+		return
+	}
+	logger.Stdout("Complete,", len(rows), "events")
+}
+
+func writeTimeline(filelocation string, rows []*timelineRow) error {
+	records := [][]string{
+		[]string{"gec", "country", "date", "event", "source", "description", "first_seen", "last_seen"},
+	}
+	for _, r := range rows {
+		records = append(records, []string{r.gec, r.country, r.date, r.event, r.source, r.description, r.firstSeen, r.lastSeen})
+	}
+	return weekly.WriteCsv(filelocation, records)
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"logger"
+	"strconv"
+	"strings"
+	"time"
+	"weekly"
+)
+
+// Lines in an ics file are folded at 75 octets, see RFC 5545 section 3.1
+const icsLineLength = 75
+
+var weeklyJsonRoot = ""
+var holidayCalendarFile = ""
+
+// holiday is a single day in the calendar
+type holiday struct {
+	uid     string
+	date    time.Time
+	summary string
+	note    string
+}
+
+// Creates an iCalendar file containing the national holidays of every
+// country for holiday_calendar_year.
+// Holidays with a fixed date and holidays on a weekday of a month, eg the
+// first Monday in June, are included. Other moveable holidays, such as those
+// which depend on the lunar calendar, are not included.
+func main() {
+	// read config
+	configBytes, err := ioutil.ReadFile("config.json")
+	if err != nil {
+		logger.Stderr("Error reading config.json")
+		logger.Stderr(err)
+		return
+	}
+	// parse config
+	config := map[string]string{}
+	err = json.Unmarshal(configBytes, &config)
+	var exists bool
+	weeklyJsonRoot, exists = config["weekly_json_root"]
+	if !exists {
+		logger.Stderr("Missing config value: weekly_json_root")
+		return
+	}
+	holidayCalendarFile, exists = config["holiday_calendar_file"]
+	if !exists {
+		logger.Stderr("Missing config value: holiday_calendar_file")
+		return
+	}
+	year := time.Now().Year()
+	yearStr, exists := config["holiday_calendar_year"]
+	if exists {
+		year, err = strconv.Atoi(yearStr)
+		if err != nil {
+			logger.Stderr("Invalid config value: holiday_calendar_year")
+			return
+		}
+	}
+	// use the latest weekly file for the year, or the latest file if there
+	// are no files for the year
+	files, err := weekly.Files(weeklyJsonRoot)
+	if err != nil {
+		logger.Stderr("Error reading weekly_json_root")
+		logger.Stderr(err)
+		return
+	}
+	if len(files) == 0 {
+		logger.Stderr("No weekly files in weekly_json_root")
+		return
+	}
+	f := files[len(files)-1]
+	for _, file := range files {
+		if file.Date.Year() == year {
+			f = file
+		}
+	}
+	logger.Stdout("Creating holiday calendar for", year, "from", f.Date.Format("2006-01-02"))
+	o, err := weekly.Load(f.Filelocation)
+	if err != nil {
+		logger.Stderr("Error loading weekly file", f.Filelocation)
+		logger.Stderr(err)
+		return
+	}
+	countries, err := weekly.Countries(o)
+	if err != nil {
+		logger.Stderr("Error getting countries from weekly file", f.Filelocation)
+		logger.Stderr(err)
+		return
+	}
+	holidays := []holiday{}
+	for _, c := range countries {
+		list := weekly.ListAtPath(c.Json, "data", "government", "national_holidays")
+		for i, h := range list {
+			date, ok := holidayDate(h, year)
+			if !ok {
+				continue
+			}
+			name, _ := weekly.ValueAtPath(h, "name")
+			nameStr, _ := name.(string)
+			note, _ := weekly.ValueAtPath(h, "note")
+			noteStr, _ := note.(string)
+			uid := strconv.Itoa(year) + "-" + c.Key + "-" + strconv.Itoa(i) + "@factbook"
+			holidays = append(holidays, holiday{
+				uid:     strings.Replace(uid, " ", "_", -1),
+				date:    date,
+				summary: c.Name + ": " + nameStr,
+				note:    noteStr,
+			})
+		}
+	}
+	err = ioutil.WriteFile(holidayCalendarFile, icsBytes(holidays), 0664)
+	if err != nil {
+		logger.Stderr("Error saving holiday_calendar_file")
+		logger.Stderr(err)
+		return
+	}
+	logger.Stdout("Complete,", len(holidays), "holidays")
+}
+
+// Returns the date of the holiday in year from the month and day, or from
+// the weekday rule
+func holidayDate(h interface{}, year int) (time.Time, bool) {
+	month, hasMonth := weekly.ValueAtPath(h, "month")
+	day, hasDay := weekly.ValueAtPath(h, "day")
+	if hasMonth && hasDay {
+		monthNum, _ := month.(float64)
+		dayNum, _ := day.(float64)
+		d := time.Date(year, time.Month(monthNum), int(dayNum), 0, 0, 0, 0, time.UTC)
+		// eg 29 February in a year which is not a leap year
+		if d.Day() != int(dayNum) {
+			return d, false
+		}
+		return d, true
+	}
+	ordinal, hasOrdinal := weekly.ValueAtPath(h, "rule", "ordinal")
+	weekday, hasWeekday := weekly.ValueAtPath(h, "rule", "weekday")
+	month, hasMonth = weekly.ValueAtPath(h, "rule", "month")
+	if !hasOrdinal || !hasWeekday || !hasMonth {
+		return time.Time{}, false
+	}
+	ordinalNum, _ := ordinal.(float64)
+	monthNum, _ := month.(float64)
+	weekdayStr, _ := weekday.(string)
+	return nthWeekday(year, time.Month(monthNum), weekdayStr, int(ordinalNum))
+}
+
+// Returns the nth weekday in the month, where -1 is the last weekday
+func nthWeekday(year int, month time.Month, weekdayStr string, n int) (time.Time, bool) {
+	weekday := -1
+	for i := time.Sunday; i <= time.Saturday; i++ {
+		if i.String() == weekdayStr {
+			weekday = int(i)
+		}
+	}
+	if weekday == -1 || n == 0 {
+		return time.Time{}, false
+	}
+	if n < 0 {
+		last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+		offset := (int(last.Weekday()) - weekday + 7) % 7
+		return last.AddDate(0, 0, -offset), true
+	}
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	offset := (weekday - int(first.Weekday()) + 7) % 7
+	d := first.AddDate(0, 0, offset+(n-1)*7)
+	if d.Month() != month {
+		return d, false
+	}
+	return d, true
+}
+
+func icsBytes(holidays []holiday) []byte {
+	var b bytes.Buffer
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	writeIcsLine(&b, "BEGIN:VCALENDAR")
+	writeIcsLine(&b, "VERSION:2.0")
+	writeIcsLine(&b, "PRODID:-//factbook//national holidays//EN")
+	writeIcsLine(&b, "CALSCALE:GREGORIAN")
+	for _, h := range holidays {
+		writeIcsLine(&b, "BEGIN:VEVENT")
+		writeIcsLine(&b, "UID:"+h.uid)
+		writeIcsLine(&b, "DTSTAMP:"+stamp)
+		writeIcsLine(&b, "DTSTART;VALUE=DATE:"+h.date.Format("20060102"))
+		writeIcsLine(&b, "DTEND;VALUE=DATE:"+h.date.AddDate(0, 0, 1).Format("20060102"))
+		writeIcsLine(&b, "SUMMARY:"+icsEscape(h.summary))
+		if len(h.note) > 0 {
+			writeIcsLine(&b, "DESCRIPTION:"+icsEscape(h.note))
+		}
+		writeIcsLine(&b, "END:VEVENT")
+	}
+	writeIcsLine(&b, "END:VCALENDAR")
+	return b.Bytes()
+}
+
+func icsEscape(s string) string {
+	s = strings.Replace(s, "\\", "\\\\", -1)
+	s = strings.Replace(s, ";", "\\;", -1)
+	s = strings.Replace(s, ",", "\\,", -1)
+	s = strings.Replace(s, "\n", "\\n", -1)
+	return s
+}
+
+// Writes a line, folding it so no line is longer than icsLineLength octets.
+// Folded lines start with a space and are not split within a character.
+func writeIcsLine(b *bytes.Buffer, line string) {
+	length := 0
+	for _, r := range line {
+		size := len(string(r))
+		if length+size > icsLineLength {
+			b.WriteString("\r\n ")
+			length = 1
+		}
+		b.WriteRune(r)
+		length = length + size
+	}
+	b.WriteString("\r\n")
+}
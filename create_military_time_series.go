@@ -0,0 +1,241 @@
+package main
+
+import (
+	"country"
+	"encoding/json"
+	"io/ioutil"
+	"logger"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"weekly"
+)
+
+var weeklyJsonRoot = ""
+var militaryTimeSeriesRoot = ""
+
+// The manpower fields, in the order they are listed in the csv
+var manpowerCategories = []string{
+	"available_for_military_service",
+	"fit_for_military_service",
+	"reaching_militarily_significant_age_annually",
+}
+
+// seriesRow is one value for a country and date along with the first and
+// last week it appears in the weekly files. Values revised between weeks
+// keep the latest value.
+type seriesRow struct {
+	gec       string
+	country   string
+	date      string
+	columns   []string
+	firstSeen string
+	lastSeen  string
+}
+
+// groupCount is a manpower count for one sex and age band
+type groupCount struct {
+	group country.ManpowerGroup
+	count interface{}
+}
+
+// series is the rows of one csv, keyed so each value is listed once
+type series struct {
+	rows      []*seriesRow
+	rowForKey map[string]*seriesRow
+}
+
+func newSeries() *series {
+	return &series{
+		rows:      []*seriesRow{},
+		rowForKey: map[string]*seriesRow{},
+	}
+}
+
+func (s *series) add(key, week string, r seriesRow) {
+	existing, exists := s.rowForKey[key]
+	if exists {
+		existing.columns = r.columns
+		existing.country = r.country
+		existing.lastSeen = week
+		return
+	}
+	r.firstSeen = week
+	r.lastSeen = week
+	s.rowForKey[key] = &r
+	s.rows = append(s.rows, &r)
+}
+
+// Creates csvs of military manpower and military expenditures for every
+// country across all the weekly files, with one row for each date the
+// factbook gives a value for, sorted by country then date.
+func main() {
+	// read config
+	configBytes, err := ioutil.ReadFile("config.json")
+	if err != nil {
+		logger.Stderr("Error reading config.json")
+		logger.Stderr(err)
+		return
+	}
+	// parse config
+	config := map[string]string{}
+	err = json.Unmarshal(configBytes, &config)
+	var exists bool
+	weeklyJsonRoot, exists = config["weekly_json_root"]
+	if !exists {
+		logger.Stderr("Missing config value: weekly_json_root")
+		return
+	}
+	militaryTimeSeriesRoot, exists = config["military_time_series_root"]
+	if !exists {
+		logger.Stderr("Missing config value: military_time_series_root")
+		return
+	}
+	err = os.MkdirAll(militaryTimeSeriesRoot, 0777)
+	if err != nil {
+		logger.Stderr("Error creating military_time_series_root")
+		logger.Stderr(err)
+		return
+	}
+	files, err := weekly.Files(weeklyJsonRoot)
+	if err != nil {
+		logger.Stderr("Error reading weekly_json_root")
+		logger.Stderr(err)
+		return
+	}
+	manpower := newSeries()
+	expenditures := newSeries()
+	for _, f := range files {
+		week := f.Date.Format("2006-01-02")
+		logger.Stdout("Reading military data for", week)
+		o, err := weekly.Load(f.Filelocation)
+		if err != nil {
+			logger.Stderr("Error loading weekly file", f.Filelocation)
+			logger.Stderr(err)
+			continue
+		}
+		countries, err := weekly.Countries(o)
+		if err != nil {
+			logger.Stderr("Error getting countries from weekly file", f.Filelocation)
+			logger.Stderr(err)
+			continue
+		}
+		for _, c := range countries {
+			military, exists := weekly.ValueAtPath(c.Json, "data", "military_and_security")
+			if !exists {
+				continue
+			}
+			gec := c.Gec
+			if gec == "" {
+				gec = c.Key
+			}
+			addManpower(manpower, military, gec, c.Name, week)
+			addExpenditures(expenditures, military, gec, c.Name, week)
+		}
+	}
+	err = writeSeries(path.Join(militaryTimeSeriesRoot, "military_manpower.csv"),
+		[]string{"category", "sex", "min_age", "max_age", "count"}, manpower)
+	if err != nil {
+		logger.Stderr("Error saving military_manpower.csv")
+		logger.Stderr(err)
+	}
+	err = writeSeries(path.Join(militaryTimeSeriesRoot, "military_expenditures.csv"),
+		[]string{"percent_of_gdp", "amount_usd"}, expenditures)
+	if err != nil {
+		logger.Stderr("Error saving military_expenditures.csv")
+		logger.Stderr(err)
+	}
+	logger.Stdout("Complete,", len(manpower.rows), "manpower rows,", len(expenditures.rows), "expenditure rows")
+}
+
+// Adds the counts for each sex and age band. Weekly files parsed before the
+// manpower fields were typed have a key for each group, eg males_age_16_49.
+func addManpower(s *series, military interface{}, gec, name, week string) {
+	for _, category := range manpowerCategories {
+		field, exists := weekly.ValueAtPath(military, "manpower", category)
+		if !exists {
+			continue
+		}
+		date := weekly.StringAtPath(field, "date")
+		counts := []groupCount{}
+		for _, record := range weekly.ListAtPath(field, "by_sex") {
+			g := country.ManpowerGroup{Sex: weekly.StringAtPath(record, "sex")}
+			minAge, hasAges := weekly.ValueAtPath(record, "min_age")
+			maxAge, _ := weekly.ValueAtPath(record, "max_age")
+			if hasAges {
+				g.MinAge = int(weekly.FloatValue(minAge))
+				g.MaxAge = int(weekly.FloatValue(maxAge))
+				g.HasAges = true
+			}
+			count, _ := weekly.ValueAtPath(record, "count")
+			counts = append(counts, groupCount{g, count})
+		}
+		for _, key := range weekly.MapKeys(field) {
+			g, err := country.StringToManpowerGroup(key)
+			if err != nil {
+				continue
+			}
+			count, _ := weekly.ValueAtPath(field, key)
+			counts = append(counts, groupCount{g, count})
+		}
+		for _, gc := range counts {
+			minAge, maxAge := "", ""
+			if gc.group.HasAges {
+				minAge = strconv.Itoa(gc.group.MinAge)
+				maxAge = strconv.Itoa(gc.group.MaxAge)
+			}
+			r := seriesRow{
+				gec:     gec,
+				country: name,
+				date:    date,
+				columns: []string{category, gc.group.Sex, minAge, maxAge, weekly.NumberString(gc.count)},
+			}
+			key := gec + "|" + date + "|" + category + "|" + gc.group.Sex + "|" + minAge + "|" + maxAge
+			s.add(key, week, r)
+		}
+	}
+}
+
+// Adds the expenditure for each year. Weekly files parsed before amounts
+// were kept have only the percent of GDP as the value. Values without a
+// date are kept with an empty date, keyed by their position in the list.
+func addExpenditures(s *series, military interface{}, gec, name, week string) {
+	for i, v := range weekly.ListAtPath(military, "expenditures", "annual_values") {
+		date := weekly.StringAtPath(v, "date")
+		key := gec + "|" + date
+		if date == "" {
+			key = key + "|" + strconv.Itoa(i)
+		}
+		percent, _ := weekly.ValueAtPath(v, "percent_of_gdp")
+		if weekly.StringAtPath(v, "units") == "percent_of_gdp" {
+			percent, _ = weekly.ValueAtPath(v, "value")
+		}
+		amount, _ := weekly.ValueAtPath(v, "amount", "value")
+		r := seriesRow{
+			gec:     gec,
+			country: name,
+			date:    date,
+			columns: []string{weekly.NumberString(percent), weekly.NumberString(amount)},
+		}
+		s.add(key, week, r)
+	}
+}
+
+func writeSeries(filelocation string, columns []string, s *series) error {
+	sort.SliceStable(s.rows, func(i, j int) bool {
+		if s.rows[i].gec != s.rows[j].gec {
+			return s.rows[i].gec < s.rows[j].gec
+		}
+		return s.rows[i].date < s.rows[j].date
+	})
+	header := append([]string{"gec", "country", "date"}, columns...)
+	records := [][]string{
+		append(header, "first_seen", "last_seen"),
+	}
+	for _, r := range s.rows {
+		record := append([]string{r.gec, r.country, r.date}, r.columns...)
+		records = append(records, append(record, r.firstSeen, r.lastSeen))
+	}
+	return weekly.WriteCsv(filelocation, records)
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"country"
+	"encoding/json"
+	"io/ioutil"
+	"logger"
+	"sort"
+	"strings"
+	"weekly"
+)
+
+var weeklyJsonRoot = ""
+var organizationMembershipChangesFile = ""
+
+// membershipChange is a country joining or leaving an organization, or a
+// change to the status of its membership, eg observer to member
+type membershipChange struct {
+	week           string
+	organization   string
+	name           string
+	gec            string
+	country        string
+	change         string
+	previousStatus string
+	status         string
+}
+
+// Creates a csv of every change to international organization membership
+// between weekly files, sorted by organization then week.
+// Countries missing from a weekly file are not reported as leaving.
+func main() {
+	// read config
+	configBytes, err := ioutil.ReadFile("config.json")
+	if err != nil {
+		logger.Stderr("Error reading config.json")
+		logger.Stderr(err)
+		return
+	}
+	// parse config
+	config := map[string]string{}
+	err = json.Unmarshal(configBytes, &config)
+	var exists bool
+	weeklyJsonRoot, exists = config["weekly_json_root"]
+	if !exists {
+		logger.Stderr("Missing config value: weekly_json_root")
+		return
+	}
+	organizationMembershipChangesFile, exists = config["organization_membership_changes_file"]
+	if !exists {
+		logger.Stderr("Missing config value: organization_membership_changes_file")
+		return
+	}
+	files, err := weekly.Files(weeklyJsonRoot)
+	if err != nil {
+		logger.Stderr("Error reading weekly_json_root")
+		logger.Stderr(err)
+		return
+	}
+	changes := []membershipChange{}
+	previousForGec := map[string]map[string]string{}
+	for _, f := range files {
+		dateStr := f.Date.Format("2006-01-02")
+		logger.Stdout("Comparing memberships for", dateStr)
+		o, err := weekly.Load(f.Filelocation)
+		if err != nil {
+			logger.Stderr("Error loading weekly file", f.Filelocation)
+			logger.Stderr(err)
+			continue
+		}
+		countries, err := weekly.Countries(o)
+		if err != nil {
+			logger.Stderr("Error getting countries from weekly file", f.Filelocation)
+			logger.Stderr(err)
+			continue
+		}
+		for _, c := range countries {
+			list, exists := weekly.ValueAtPath(c.Json, "data", "government", "international_organization_participation")
+			if !exists {
+				continue
+			}
+			gec := c.Gec
+			if gec == "" {
+				gec = c.Key
+			}
+			memberships := membershipStatuses(list)
+			previous, hasPrevious := previousForGec[gec]
+			previousForGec[gec] = memberships
+			if !hasPrevious {
+				continue
+			}
+			newChange := func(organization, change, previousStatus, status string) membershipChange {
+				name, _ := country.OrganizationName(organization)
+				return membershipChange{dateStr, organization, name, gec, c.Name, change, previousStatus, status}
+			}
+			for organization, status := range memberships {
+				previousStatus, wasMember := previous[organization]
+				if !wasMember {
+					changes = append(changes, newChange(organization, "join", "", status))
+				} else if previousStatus != status {
+					changes = append(changes, newChange(organization, "status_change", previousStatus, status))
+				}
+			}
+			for organization, previousStatus := range previous {
+				if _, isMember := memberships[organization]; !isMember {
+					changes = append(changes, newChange(organization, "exit", previousStatus, ""))
+				}
+			}
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.organization != b.organization {
+			return a.organization < b.organization
+		}
+		if a.week != b.week {
+			return a.week < b.week
+		}
+		return a.gec < b.gec
+	})
+	err = writeChanges(organizationMembershipChangesFile, changes)
+	if err != nil {
+		logger.Stderr("Error saving organization_membership_changes_file")
+		logger.Stderr(err)
+		return
+	}
+	logger.Stdout("Complete,", len(changes), "changes")
+}
+
+// Returns the status for each organization.
+// Weekly files parsed before the status was added are read from the note.
+func membershipStatuses(list interface{}) map[string]string {
+	memberships := map[string]string{}
+	items, isList := list.([]interface{})
+	if !isList {
+		return memberships
+	}
+	for _, item := range items {
+		organization, _ := weekly.ValueAtPath(item, "organization")
+		organizationStr, _ := organization.(string)
+		// weekly files parsed before names were trimmed have names such as
+		// "WTO " which must match the same organization in later files
+		organizationStr = strings.TrimSpace(organizationStr)
+		if organizationStr == "" {
+			continue
+		}
+		status, exists := weekly.ValueAtPath(item, "status")
+		statusStr, _ := status.(string)
+		if !exists {
+			note, _ := weekly.ValueAtPath(item, "note")
+			noteStr, _ := note.(string)
+			statusStr = country.MembershipStatus(noteStr)
+		}
+		memberships[organizationStr] = statusStr
+	}
+	return memberships
+}
+
+func writeChanges(filelocation string, changes []membershipChange) error {
+	rows := [][]string{
+		[]string{"week", "organization", "name", "gec", "country", "change", "previous_status", "status"},
+	}
+	for _, c := range changes {
+		rows = append(rows, []string{c.week, c.organization, c.name, c.gec, c.country, c.change, c.previousStatus, c.status})
+	}
+	return weekly.WriteCsv(filelocation, rows)
+}
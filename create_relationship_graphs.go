@@ -0,0 +1,283 @@
+package main
+
+import (
+	"bytes"
+	"country"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"logger"
+	"math"
+	"os"
+	"path"
+	"strconv"
+	"weekly"
+)
+
+// Border lengths which differ by more than this are reported in the checks
+const borderLengthToleranceKm = 1.0
+
+var weeklyJsonRoot = ""
+var relationshipGraphRoot = ""
+
+// edge is a relationship from one country to another as listed on the page
+// for the source country. Countries are GEC codes.
+type edge struct {
+	source       string
+	target       string
+	relationship string
+	value        float64
+	units        string
+}
+
+// Creates an edge list and GraphML file for every weekly file, covering land
+// borders, export partners and import partners, along with a list of
+// borders which do not agree between the two countries.
+func main() {
+	// read config
+	configBytes, err := ioutil.ReadFile("config.json")
+	if err != nil {
+		logger.Stderr("Error reading config.json")
+		logger.Stderr(err)
+		return
+	}
+	// parse config
+	config := map[string]string{}
+	err = json.Unmarshal(configBytes, &config)
+	var exists bool
+	weeklyJsonRoot, exists = config["weekly_json_root"]
+	if !exists {
+		logger.Stderr("Missing config value: weekly_json_root")
+		return
+	}
+	relationshipGraphRoot, exists = config["relationship_graph_root"]
+	if !exists {
+		logger.Stderr("Missing config value: relationship_graph_root")
+		return
+	}
+	err = os.MkdirAll(relationshipGraphRoot, 0777)
+	if err != nil {
+		logger.Stderr("Error creating relationship_graph_root")
+		logger.Stderr(err)
+		return
+	}
+	// create graphs for every week
+	files, err := weekly.Files(weeklyJsonRoot)
+	if err != nil {
+		logger.Stderr("Error reading weekly_json_root")
+		logger.Stderr(err)
+		return
+	}
+	for _, f := range files {
+		logger.Stdout("Creating relationship graph for", f.Date.Format("2006-01-02"))
+		createGraphsForFile(f)
+	}
+	logger.Stdout("Complete")
+}
+
+func createGraphsForFile(f weekly.File) {
+	o, err := weekly.Load(f.Filelocation)
+	if err != nil {
+		logger.Stderr("Error loading weekly file", f.Filelocation)
+		logger.Stderr(err)
+		return
+	}
+	countries, err := weekly.Countries(o)
+	if err != nil {
+		logger.Stderr("Error getting countries from weekly file", f.Filelocation)
+		logger.Stderr(err)
+		return
+	}
+	edges := []edge{}
+	checks := [][]string{}
+	names := map[string]string{}
+	for _, c := range countries {
+		if c.Gec == "" {
+			checks = append(checks, []string{"unknown_country", c.Key, "", c.Name})
+			continue
+		}
+		names[c.Gec] = c.Name
+		// land borders
+		borders := weekly.ListAtPath(c.Json, "data", "geography", "land_boundaries", "border_countries")
+		for _, border := range borders {
+			e, ok := edgeForListItem(c, border, "country", "land_border")
+			if !ok {
+				checks = append(checks, unresolvedCheck(c, border, "country"))
+				continue
+			}
+			length, _ := weekly.ValueAtPath(border, "border_length", "value")
+			e.value, _ = length.(float64)
+			units, _ := weekly.ValueAtPath(border, "border_length", "units")
+			e.units, _ = units.(string)
+			edges = append(edges, e)
+		}
+		// trade partners
+		for _, direction := range []string{"exports", "imports"} {
+			partners := weekly.ListAtPath(c.Json, "data", "economy", direction, "partners", "by_country")
+			for _, partner := range partners {
+				e, ok := edgeForListItem(c, partner, "name", direction[0:len(direction)-1]+"_partner")
+				if !ok {
+					checks = append(checks, unresolvedCheck(c, partner, "name"))
+					continue
+				}
+				percent, _ := weekly.ValueAtPath(partner, "percent")
+				e.value, _ = percent.(float64)
+				e.units = "%"
+				edges = append(edges, e)
+			}
+		}
+	}
+	checks = append(checks, borderChecks(edges)...)
+	// save the results
+	dateStr := f.Date.Format("2006-01-02")
+	err = writeEdgeList(path.Join(relationshipGraphRoot, dateStr+"_edges.csv"), edges)
+	if err != nil {
+		logger.Stderr("Error saving edge list for", dateStr)
+		logger.Stderr(err)
+	}
+	err = writeGraphML(path.Join(relationshipGraphRoot, dateStr+"_relationships.graphml"), edges, names)
+	if err != nil {
+		logger.Stderr("Error saving graphml for", dateStr)
+		logger.Stderr(err)
+	}
+	err = writeChecks(path.Join(relationshipGraphRoot, dateStr+"_checks.csv"), checks)
+	if err != nil {
+		logger.Stderr("Error saving checks for", dateStr)
+		logger.Stderr(err)
+	}
+}
+
+// Resolves the country name in a border or partner item to a GEC code.
+// Regions such as the European Union are resolved if they have a GEC code.
+func edgeForListItem(c weekly.Country, item interface{}, nameKey, relationship string) (edge, bool) {
+	e := edge{
+		source:       c.Gec,
+		relationship: relationship,
+	}
+	name, _ := weekly.ValueAtPath(item, nameKey)
+	nameStr, _ := name.(string)
+	codes, exists := country.CodesForCountryName(nameStr)
+	if !exists {
+		return e, false
+	}
+	e.target = codes.Gec
+	return e, true
+}
+
+func unresolvedCheck(c weekly.Country, item interface{}, nameKey string) []string {
+	name, _ := weekly.ValueAtPath(item, nameKey)
+	nameStr, _ := name.(string)
+	return []string{"unresolved_name", c.Gec, "", nameStr}
+}
+
+// Returns borders listed by only one of the two countries, and borders where
+// the two countries list different lengths
+func borderChecks(edges []edge) [][]string {
+	checks := [][]string{}
+	lengths := map[string]map[string]float64{}
+	hasBorders := map[string]bool{}
+	for _, e := range edges {
+		if e.relationship != "land_border" {
+			continue
+		}
+		if lengths[e.source] == nil {
+			lengths[e.source] = map[string]float64{}
+		}
+		lengths[e.source][e.target] = e.value
+		hasBorders[e.source] = true
+	}
+	for _, e := range edges {
+		if e.relationship != "land_border" {
+			continue
+		}
+		// countries without any border data are not checked
+		if !hasBorders[e.target] {
+			continue
+		}
+		reverseLength, exists := lengths[e.target][e.source]
+		if !exists {
+			details := e.source + " lists " + e.target + " but " + e.target + " does not list " + e.source
+			checks = append(checks, []string{"asymmetric_border", e.source, e.target, details})
+			continue
+		}
+		// report each pair of lengths once
+		if e.source > e.target {
+			continue
+		}
+		if math.Abs(e.value-reverseLength) > borderLengthToleranceKm {
+			details := formatFloat(e.value) + " " + e.units + " vs " + formatFloat(reverseLength) + " " + e.units
+			checks = append(checks, []string{"border_length_mismatch", e.source, e.target, details})
+		}
+	}
+	return checks
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func writeEdgeList(filelocation string, edges []edge) error {
+	rows := [][]string{
+		[]string{"source", "target", "relationship", "value", "units"},
+	}
+	for _, e := range edges {
+		rows = append(rows, []string{e.source, e.target, e.relationship, formatFloat(e.value), e.units})
+	}
+	return weekly.WriteCsv(filelocation, rows)
+}
+
+func writeChecks(filelocation string, checks [][]string) error {
+	rows := [][]string{
+		[]string{"check", "source", "target", "details"},
+	}
+	rows = append(rows, checks...)
+	return weekly.WriteCsv(filelocation, rows)
+}
+
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func writeGraphML(filelocation string, edges []edge, names map[string]string) error {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	b.WriteString(`  <key id="name" for="node" attr.name="name" attr.type="string"/>` + "\n")
+	b.WriteString(`  <key id="relationship" for="edge" attr.name="relationship" attr.type="string"/>` + "\n")
+	b.WriteString(`  <key id="value" for="edge" attr.name="value" attr.type="double"/>` + "\n")
+	b.WriteString(`  <key id="units" for="edge" attr.name="units" attr.type="string"/>` + "\n")
+	b.WriteString(`  <graph id="relationships" edgedefault="directed">` + "\n")
+	// nodes include countries which are only a target, eg partners which
+	// do not have a page in the weekly file
+	nodes := []string{}
+	seen := map[string]bool{}
+	for _, e := range edges {
+		for _, gec := range []string{e.source, e.target} {
+			if !seen[gec] {
+				seen[gec] = true
+				nodes = append(nodes, gec)
+			}
+		}
+	}
+	for _, gec := range nodes {
+		name, exists := names[gec]
+		if !exists {
+			codes, _ := country.CodesForFilename(gec + ".html")
+			name = codes.Name
+		}
+		b.WriteString(fmt.Sprintf(`    <node id="%s"><data key="name">%s</data></node>`+"\n", xmlEscape(gec), xmlEscape(name)))
+	}
+	for i, e := range edges {
+		b.WriteString(fmt.Sprintf(`    <edge id="e%d" source="%s" target="%s">`, i, xmlEscape(e.source), xmlEscape(e.target)))
+		b.WriteString(fmt.Sprintf(`<data key="relationship">%s</data>`, e.relationship))
+		b.WriteString(fmt.Sprintf(`<data key="value">%s</data>`, formatFloat(e.value)))
+		b.WriteString(fmt.Sprintf(`<data key="units">%s</data>`, xmlEscape(e.units)))
+		b.WriteString("</edge>\n")
+	}
+	b.WriteString("  </graph>\n")
+	b.WriteString("</graphml>\n")
+	return ioutil.WriteFile(filelocation, b.Bytes(), 0664)
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"country"
+	"encoding/json"
+	"io/ioutil"
+	"logger"
+	"regexp"
+	"sort"
+	"weekly"
+)
+
+var weeklyJsonRoot = ""
+var traffickingTierHistoryFile = ""
+
+// eg the (2015) at the end of tier ratings in weekly files parsed before the
+// tier was typed
+var tierRatingYearRe = regexp.MustCompile(`\(([0-9]{4})\)\s*$`)
+
+// tierPeriod is the weeks a country held one tier for one report year, and
+// how the tier changed from the period before it
+type tierPeriod struct {
+	gec          string
+	country      string
+	tier         country.TraffickingTier
+	date         string
+	firstSeen    string
+	lastSeen     string
+	change       string
+	previousTier string
+}
+
+// Creates a csv of the trafficking in persons tier of every country across
+// all the weekly files, with a row each time the tier or its report year
+// changes, flagging upgrades and downgrades, sorted by country then week.
+func main() {
+	// read config
+	configBytes, err := ioutil.ReadFile("config.json")
+	if err != nil {
+		logger.Stderr("Error reading config.json")
+		logger.Stderr(err)
+		return
+	}
+	// parse config
+	config := map[string]string{}
+	err = json.Unmarshal(configBytes, &config)
+	var exists bool
+	weeklyJsonRoot, exists = config["weekly_json_root"]
+	if !exists {
+		logger.Stderr("Missing config value: weekly_json_root")
+		return
+	}
+	traffickingTierHistoryFile, exists = config["trafficking_tier_history_file"]
+	if !exists {
+		logger.Stderr("Missing config value: trafficking_tier_history_file")
+		return
+	}
+	files, err := weekly.Files(weeklyJsonRoot)
+	if err != nil {
+		logger.Stderr("Error reading weekly_json_root")
+		logger.Stderr(err)
+		return
+	}
+	periods := []*tierPeriod{}
+	latestForGec := map[string]*tierPeriod{}
+	for _, f := range files {
+		dateStr := f.Date.Format("2006-01-02")
+		logger.Stdout("Reading trafficking tiers for", dateStr)
+		o, err := weekly.Load(f.Filelocation)
+		if err != nil {
+			logger.Stderr("Error loading weekly file", f.Filelocation)
+			logger.Stderr(err)
+			continue
+		}
+		countries, err := weekly.Countries(o)
+		if err != nil {
+			logger.Stderr("Error getting countries from weekly file", f.Filelocation)
+			logger.Stderr(err)
+			continue
+		}
+		for _, c := range countries {
+			rating, exists := weekly.ValueAtPath(c.Json, "data", "transnational_issues", "trafficking_in_persons", "tier_rating")
+			if !exists {
+				continue
+			}
+			tier, date, hasTier := tierAndDate(rating)
+			if !hasTier {
+				continue
+			}
+			gec := c.Gec
+			if gec == "" {
+				gec = c.Key
+			}
+			latest, hasLatest := latestForGec[gec]
+			if hasLatest && latest.tier == tier && latest.date == date {
+				latest.lastSeen = dateStr
+				latest.country = c.Name
+				continue
+			}
+			p := &tierPeriod{
+				gec:       gec,
+				country:   c.Name,
+				tier:      tier,
+				date:      date,
+				firstSeen: dateStr,
+				lastSeen:  dateStr,
+			}
+			if hasLatest {
+				p.change = tierChange(latest.tier, tier)
+				p.previousTier = latest.tier.Key()
+			}
+			latestForGec[gec] = p
+			periods = append(periods, p)
+		}
+	}
+	sort.SliceStable(periods, func(i, j int) bool {
+		if periods[i].gec != periods[j].gec {
+			return periods[i].gec < periods[j].gec
+		}
+		return periods[i].firstSeen < periods[j].firstSeen
+	})
+	err = writePeriods(traffickingTierHistoryFile, periods)
+	if err != nil {
+		logger.Stderr("Error saving trafficking_tier_history_file")
+		logger.Stderr(err)
+		return
+	}
+	logger.Stdout("Complete,", len(periods), "rows")
+}
+
+// Returns the tier and report year. Weekly files parsed before the tier was
+// typed have the tier rating as text, eg "Tier 2 Watch List - ... (2015)".
+func tierAndDate(rating interface{}) (country.TraffickingTier, string, bool) {
+	text, isText := rating.(string)
+	if isText {
+		tier, err := country.StringToTraffickingTier(text)
+		if err != nil {
+			return tier, "", false
+		}
+		date := ""
+		m := tierRatingYearRe.FindStringSubmatch(text)
+		if m != nil {
+			date = m[1]
+		}
+		return tier, date, true
+	}
+	key, _ := weekly.ValueAtPath(rating, "tier")
+	keyStr, _ := key.(string)
+	tier, err := country.StringToTraffickingTier(keyStr)
+	if err != nil {
+		return tier, "", false
+	}
+	date, _ := weekly.ValueAtPath(rating, "date")
+	dateStr, _ := date.(string)
+	return tier, dateStr, true
+}
+
+// Returns upgrade or downgrade, unchanged for the same tier in a new report
+// year, or reclassified to or from a special case
+func tierChange(previous, current country.TraffickingTier) string {
+	if !previous.IsRanked() || !current.IsRanked() {
+		if previous == current {
+			return "unchanged"
+		}
+		return "reclassified"
+	}
+	if current < previous {
+		return "upgrade"
+	}
+	if current > previous {
+		return "downgrade"
+	}
+	return "unchanged"
+}
+
+func writePeriods(filelocation string, periods []*tierPeriod) error {
+	rows := [][]string{
+		[]string{"gec", "country", "tier", "date", "first_seen", "last_seen", "change", "previous_tier"},
+	}
+	for _, p := range periods {
+		rows = append(rows, []string{p.gec, p.country, p.tier.Key(), p.date, p.firstSeen, p.lastSeen, p.change, p.previousTier})
+	}
+	return weekly.WriteCsv(filelocation, rows)
+}
@@ -9,12 +9,16 @@ import (
 	"os"
 	"path"
 	"scraper"
+	"sort"
+	"strings"
 	"time"
+	"weekly"
 )
 
 var countryHtmlRoot = ""
 var countryJsonRoot = ""
 var weeklyJsonRoot = ""
+var countryKey = "name"
 
 // Combines data for every country for every Monday
 func main() {
@@ -41,6 +45,15 @@ func main() {
 	if !exists {
 		logger.Stderr("Missing config value: weekly_json_root")
 	}
+	// countries are keyed by name unless otherwise specified
+	configCountryKey, exists := config["country_key"]
+	if exists {
+		countryKey = configCountryKey
+	}
+	if countryKey != "name" && countryKey != "gec" && countryKey != "iso_alpha2" && countryKey != "iso_alpha3" {
+		logger.Stderr("Invalid config value: country_key must be name, gec, iso_alpha2 or iso_alpha3")
+		return
+	}
 	// get first date
 	firstDate, err := scraper.FirstDate(countryHtmlRoot)
 	if err != nil {
@@ -82,8 +95,13 @@ func parseForDate(d time.Time) {
 	}
 	// prepare the data container to hold the parsed result
 	countries := orderedmap.New()
+	disputes := newDisputeGraph()
 	// iterate over countries and get json
 	for _, f := range countryFilenames {
+		// aggregates are saved separately
+		if country.IsAggregate(f) {
+			continue
+		}
 		c := country.ForFilename(f)
 		if err != nil {
 			logger.Stderr("Error getting country for", f, "on date", d)
@@ -99,8 +117,22 @@ func parseForDate(d time.Time) {
 			logger.Stderr(err)
 			continue
 		}
+		addDerivedIndicators(cj)
 		// save the values for this country to the final result
-		countries.Set(namekey, cj)
+		countries.Set(keyForCountry(f, namekey), cj)
+		disputes.addCountry(f, cj)
+	}
+	// get json for the world, the EU and the oceans
+	aggregates := orderedmap.New()
+	for _, f := range country.AggregateFilenames {
+		c := country.ForFilename(f)
+		c.ClearCacheAfter(d)
+		aj, namekey, err := c.JsonForDate(d, countryHtmlRoot, countryJsonRoot)
+		if err != nil {
+			// some aggregates are not in older archives
+			continue
+		}
+		aggregates.Set(keyForCountry(f, namekey), aj)
 	}
 	// prepare metadata
 	metadata := orderedmap.New()
@@ -110,6 +142,8 @@ func parseForDate(d time.Time) {
 	// save the parsed data
 	parsed := orderedmap.New()
 	parsed.Set("countries", countries)
+	parsed.Set("aggregates", aggregates)
+	parsed.Set("dispute_graph", disputes.toMap())
 	parsed.Set("metadata", metadata)
 	content, err := json.MarshalIndent(parsed, "", "  ")
 	if err != nil {
@@ -134,6 +168,136 @@ func parseForDate(d time.Time) {
 	}
 }
 
+// Adds the derived section to json parsed before derived indicators were
+// added to the parser
+func addDerivedIndicators(cj *orderedmap.OrderedMap) {
+	dataInterface, exists := cj.Get("data")
+	if !exists {
+		return
+	}
+	var data *orderedmap.OrderedMap
+	switch m := dataInterface.(type) {
+	case *orderedmap.OrderedMap:
+		data = m
+	case orderedmap.OrderedMap:
+		data = &m
+	default:
+		return
+	}
+	if _, hasDerived := data.Get("derived"); hasDerived {
+		return
+	}
+	derived, err := country.DerivedIndicators(data)
+	if err != nil {
+		return
+	}
+	data.Set("derived", derived)
+	cj.Set("data", data)
+}
+
+// Returns the key for the country depending on the country_key config value.
+// Entities without an ISO code, or which share an ISO code with other
+// entities, use the GEC code, eg Wake Island is wq rather than UM.
+// Historical GEC codes use the ISO code of their entity, eg Serbia is RS
+// whether the page is rb or ri.
+func keyForCountry(f, namekey string) string {
+	if countryKey == "name" {
+		return namekey
+	}
+	gec := strings.TrimSuffix(f, ".html")
+	codes, exists := country.CodesForFilename(f)
+	if !exists || countryKey == "gec" || codes.SharesIsoCode() {
+		return gec
+	}
+	if countryKey == "iso_alpha2" && codes.IsoAlpha2 != "" {
+		return codes.IsoAlpha2
+	}
+	if countryKey == "iso_alpha3" && codes.IsoAlpha3 != "" {
+		return codes.IsoAlpha3
+	}
+	return gec
+}
+
+// Disputes between two countries are a single edge regardless of which
+// country or countries report the dispute. Countries are GEC codes.
+type disputeEdge struct {
+	countries  []string
+	reportedBy []string
+	categories []string
+}
+
+type disputeGraph struct {
+	edges map[string]*disputeEdge
+}
+
+func newDisputeGraph() disputeGraph {
+	return disputeGraph{
+		edges: map[string]*disputeEdge{},
+	}
+}
+
+func (g disputeGraph) addCountry(f string, cj *orderedmap.OrderedMap) {
+	gec := strings.TrimSuffix(f, ".html")
+	disputes := weekly.ListAtPath(cj, "data", "transnational_issues", "disputes")
+	for _, disputeInterface := range disputes {
+		category, _ := weekly.ValueAtPath(disputeInterface, "category")
+		categoryStr, _ := category.(string)
+		for _, otherInterface := range weekly.ListAtPath(disputeInterface, "countries") {
+			other, isString := otherInterface.(string)
+			if !isString || other == gec {
+				continue
+			}
+			g.addEdge(gec, other, categoryStr)
+		}
+	}
+}
+
+func (g disputeGraph) addEdge(reporter, other, category string) {
+	countries := []string{reporter, other}
+	sort.Strings(countries)
+	key := strings.Join(countries, "-")
+	e, exists := g.edges[key]
+	if !exists {
+		e = &disputeEdge{
+			countries: countries,
+		}
+		g.edges[key] = e
+	}
+	e.reportedBy = appendUnique(e.reportedBy, reporter)
+	if category != "" {
+		e.categories = appendUnique(e.categories, category)
+	}
+}
+
+func (g disputeGraph) toMap() *orderedmap.OrderedMap {
+	keys := []string{}
+	for key, _ := range g.edges {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	edges := []*orderedmap.OrderedMap{}
+	for _, key := range keys {
+		e := g.edges[key]
+		edge := orderedmap.New()
+		edge.Set("countries", e.countries)
+		edge.Set("reported_by", e.reportedBy)
+		edge.Set("categories", e.categories)
+		edges = append(edges, edge)
+	}
+	o := orderedmap.New()
+	o.Set("edges", edges)
+	return o
+}
+
+func appendUnique(l []string, s string) []string {
+	for _, existing := range l {
+		if existing == s {
+			return l
+		}
+	}
+	return append(l, s)
+}
+
 func mondayBefore(date time.Time) time.Time {
 	daysDifference := (int(date.Weekday()-time.Monday) + 7) % 7
 	if daysDifference == 0 {
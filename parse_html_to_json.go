@@ -88,6 +88,10 @@ func main() {
 					logger.Stderr(err)
 					return
 				}
+				// flag any units which can't be normalised
+				for _, u := range country.UnknownUnits(p.ParsedData) {
+					logger.Stderr("Unknown units", filelocation, u)
+				}
 				// save the parsed json
 				content, err := json.MarshalIndent(p.ParsedData, "", "  ")
 				if err != nil {
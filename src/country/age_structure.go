@@ -0,0 +1,121 @@
+package country
+
+import (
+	"errors"
+	"orderedmap"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var StringToAgeBracketErr = errors.New("String is not an age bracket")
+
+// eg 0-14 years: 18.73% (male 31,255,995/female 29,919,938)
+var ageRangeRe = regexp.MustCompile(`^\s*([0-9]+)\s*-\s*([0-9]+) years:\s*(.*)$`)
+
+// eg 65 years and over: 15.25% (male 22,277,108/female 28,050,488)
+var ageAndOverRe = regexp.MustCompile(`^\s*([0-9]+) years and over:\s*(.*)$`)
+
+var agePercentRe = regexp.MustCompile(`([0-9]+(?:\.[0-9]+)?)\s*%`)
+var ageMalesRe = regexp.MustCompile(`\bmale ([0-9,]+)`)
+var ageFemalesRe = regexp.MustCompile(`\bfemale ([0-9,]+)`)
+
+// ageBracket is one line of the age structure. Brackets with hasMaxAge false
+// include every age from minAge, eg 65 years and over.
+type ageBracket struct {
+	minAge     int
+	maxAge     int
+	hasMaxAge  bool
+	percent    float64
+	hasPercent bool
+	males      int
+	hasMales   bool
+	females    int
+	hasFemales bool
+}
+
+// The key used for the bracket in the age structure, eg 0_to_14 or
+// 65_and_over
+func (b ageBracket) key() string {
+	if b.hasMaxAge {
+		return strconv.Itoa(b.minAge) + "_to_" + strconv.Itoa(b.maxAge)
+	}
+	return strconv.Itoa(b.minAge) + "_and_over"
+}
+
+func (b ageBracket) toMap() *orderedmap.OrderedMap {
+	o := orderedmap.New()
+	if b.hasPercent {
+		o.Set("percent", b.percent)
+	}
+	if b.hasMales {
+		o.Set("males", b.males)
+	}
+	if b.hasFemales {
+		o.Set("females", b.females)
+	}
+	return o
+}
+
+func countFromMatch(re *regexp.Regexp, s string) (int, bool) {
+	m := re.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	count, err := strconv.Atoi(strings.Replace(m[1], ",", "", -1))
+	return count, err == nil
+}
+
+// Converts a line of the age structure field into a bracket
+func stringToAgeBracket(s string) (ageBracket, error) {
+	b := ageBracket{}
+	rest := ""
+	m := ageRangeRe.FindStringSubmatch(s)
+	if m != nil {
+		b.minAge, _ = strconv.Atoi(m[1])
+		b.maxAge, _ = strconv.Atoi(m[2])
+		b.hasMaxAge = true
+		rest = m[3]
+	} else {
+		m = ageAndOverRe.FindStringSubmatch(s)
+		if m == nil {
+			return b, StringToAgeBracketErr
+		}
+		b.minAge, _ = strconv.Atoi(m[1])
+		rest = m[2]
+	}
+	pm := agePercentRe.FindStringSubmatch(rest)
+	if pm != nil {
+		b.percent, _ = strconv.ParseFloat(pm[1], 64)
+		b.hasPercent = true
+	}
+	b.males, b.hasMales = countFromMatch(ageMalesRe, rest)
+	b.females, b.hasFemales = countFromMatch(ageFemalesRe, rest)
+	return b, nil
+}
+
+// Returns the brackets ordered from youngest to oldest, each with the ages
+// it covers
+func agePyramid(brackets []ageBracket) []*orderedmap.OrderedMap {
+	sorted := make([]ageBracket, len(brackets))
+	copy(sorted, brackets)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].minAge < sorted[j].minAge
+	})
+	pyramid := []*orderedmap.OrderedMap{}
+	for _, b := range sorted {
+		o := orderedmap.New()
+		o.Set("min_age", b.minAge)
+		if b.hasMaxAge {
+			o.Set("max_age", b.maxAge)
+		}
+		values := b.toMap()
+		for _, key := range values.Keys() {
+			v, _ := values.Get(key)
+			o.Set(key, v)
+		}
+		pyramid = append(pyramid, o)
+	}
+	return pyramid
+}
@@ -0,0 +1,78 @@
+package country
+
+import (
+	"orderedmap"
+	"testing"
+)
+
+type AgeStructureCase struct {
+	s                string
+	expectedKeys     []string
+	expectedMinAges  []int
+	expectedMales    []int
+	expectedLastOpen bool
+}
+
+var ageStructureCases = []AgeStructureCase{
+	AgeStructureCase{
+		s: "0-14 years: 18.73% (male 31,255,995/female 29,919,938)\n" +
+			"15-24 years: 13.27% (male 22,213,952/female 21,137,826)\n" +
+			"25-54 years: 39.45% (male 64,528,326/female 64,334,259)\n" +
+			"55-64 years: 12.91% (male 20,357,362/female 21,821,999)\n" +
+			"65 years and over: 15.63% (male 22,678,185/female 28,376,478) (2017 est.)",
+		expectedKeys:     []string{"0_to_14", "15_to_24", "25_to_54", "55_to_64", "65_and_over"},
+		expectedMinAges:  []int{0, 15, 25, 55, 65},
+		expectedMales:    []int{31255995, 22213952, 64528326, 20357362, 22678185},
+		expectedLastOpen: true,
+	},
+	// older layout with three brackets and semicolons
+	AgeStructureCase{
+		s: "0-14 years: 20.1% (male 31,033,457; female 29,617,263)\n" +
+			"15-64 years: 67.2% (male 101,390,148; female 101,280,390)\n" +
+			"65 years and over: 12.7% (male 16,420,468; female 21,928,545) (2008 est.)",
+		expectedKeys:     []string{"0_to_14", "15_to_64", "65_and_over"},
+		expectedMinAges:  []int{0, 15, 65},
+		expectedMales:    []int{31033457, 101390148, 16420468},
+		expectedLastOpen: true,
+	},
+}
+
+func TestAgeStructure(t *testing.T) {
+	for testIndex, c := range ageStructureCases {
+		v, err := ageStructure(c.s)
+		if err != nil {
+			t.Error("ageStructure error, testIndex: ", testIndex, err)
+			continue
+		}
+		o := v.(*orderedmap.OrderedMap)
+		for _, key := range c.expectedKeys {
+			if _, exists := o.Get(key); !exists {
+				t.Error("ageStructure missing key, testIndex: ", testIndex, key)
+			}
+		}
+		p, _ := o.Get("pyramid")
+		pyramid := p.([]*orderedmap.OrderedMap)
+		if len(pyramid) != len(c.expectedMinAges) {
+			t.Error("ageStructure pyramid length, testIndex: ", testIndex, len(pyramid))
+			continue
+		}
+		for i, bracket := range pyramid {
+			minAge, _ := bracket.Get("min_age")
+			if minAge != c.expectedMinAges[i] {
+				t.Error("ageStructure min_age, testIndex: ", testIndex, i, minAge)
+			}
+			males, _ := bracket.Get("males")
+			if males != c.expectedMales[i] {
+				t.Error("ageStructure males, testIndex: ", testIndex, i, males)
+			}
+		}
+		_, hasMaxAge := pyramid[len(pyramid)-1].Get("max_age")
+		if hasMaxAge == c.expectedLastOpen {
+			t.Error("ageStructure last bracket max_age, testIndex: ", testIndex)
+		}
+		date, _ := o.Get("date")
+		if date == nil {
+			t.Error("ageStructure date, testIndex: ", testIndex)
+		}
+	}
+}
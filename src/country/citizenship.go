@@ -0,0 +1,119 @@
+package country
+
+import (
+	"orderedmap"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Citizenship keys which start with yes or no
+var citizenshipYesNoKeys = []string{
+	"citizenship_by_birth",
+	"dual_citizenship_recognized",
+}
+
+const residencyKey = "residency_requirement_for_naturalization"
+
+// eg 3 out of the previous 5 years, see Botswana
+var residencyOutOfRe = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?) (?:years? )?out of (?:the )?(?:previous|last|preceding) ([0-9]+) years`)
+
+// eg 5 years, 6 months
+var residencyDurationRe = regexp.MustCompile(`([0-9]+(?:\.[0-9]+)?) (year|month)s?\b`)
+
+// eg the ", " or " and " between 5 years, 6 months
+var residencyJoinRe = regexp.MustCompile(`^\s*(?:,|and|,\s*and)\s*$`)
+
+var yesNoRe = regexp.MustCompile(`(?i)^(yes|no)\b[\s,;:.-]*(.*)$`)
+
+// Returns the value of a string starting with yes or no and the exception
+// which follows it, eg "no, except in cases where..."
+// Returns false for hasValue if the string does not start with yes or no.
+func YesNo(s string) (value bool, exception string, hasValue bool) {
+	m := yesNoRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return false, "", false
+	}
+	return strings.ToLower(m[1]) == "yes", strings.TrimSpace(m[2]), true
+}
+
+// Converts "yes", "no" or "no, except in cases where..." into a value and
+// the exception which follows it
+func stringToYesNo(s string) (*orderedmap.OrderedMap, error) {
+	o := orderedmap.New()
+	value, exception, hasValue := YesNo(s)
+	if !hasValue {
+		return o, NoValueErr
+	}
+	o.Set("value", value)
+	if len(exception) > 0 {
+		o.Set("exception", exception)
+	}
+	return o, nil
+}
+
+// Returns the number of years of residency required for naturalization from
+// the first requirement, eg 5.5 for "5 years, 6 months; 3 years if married to
+// a citizen". Years and months which follow each other are added together.
+// Returns false for hasYears if there is no duration.
+func ResidencyYears(s string) (years float64, hasYears bool) {
+	s = strings.TrimSpace(s)
+	m := residencyOutOfRe.FindStringSubmatch(s)
+	if m != nil {
+		years, _ = strconv.ParseFloat(m[1], 64)
+		return years, true
+	}
+	first := strings.Split(s, ";")[0]
+	matches := residencyDurationRe.FindAllStringSubmatchIndex(first, -1)
+	previousEnd := -1
+	for _, i := range matches {
+		// only durations joined by a comma or and, eg 5 years, 6 months
+		if previousEnd > -1 && !residencyJoinRe.MatchString(first[previousEnd:i[0]]) {
+			break
+		}
+		n, _ := strconv.ParseFloat(first[i[2]:i[3]], 64)
+		if first[i[4]:i[5]] == "month" {
+			n = n / 12
+		}
+		years = years + n
+		previousEnd = i[1]
+	}
+	return years, previousEnd > -1
+}
+
+// Converts the residency requirement into the number of years, keeping the
+// original text since many countries have shorter requirements in some cases,
+// eg "5 years; 3 years if married to a citizen"
+func stringToResidency(s string) (*orderedmap.OrderedMap, error) {
+	o := orderedmap.New()
+	s = strings.TrimSpace(s)
+	years, hasYears := ResidencyYears(s)
+	if !hasYears {
+		return o, NoValueErr
+	}
+	o.Set("years", years)
+	o.Set("text", s)
+	return o, nil
+}
+
+// Replaces the citizenship strings with typed values where they can be
+// converted. Values which can not be converted are left as strings.
+func typedCitizenship(o *orderedmap.OrderedMap) {
+	for _, key := range citizenshipYesNoKeys {
+		v, exists := o.Get(key)
+		if !exists {
+			continue
+		}
+		typed, err := stringToYesNo(v.(string))
+		if err == nil {
+			o.Set(key, typed)
+		}
+	}
+	v, exists := o.Get(residencyKey)
+	if exists {
+		typed, err := stringToResidency(v.(string))
+		if err == nil {
+			o.Set(residencyKey, typed)
+		}
+	}
+}
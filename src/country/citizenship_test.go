@@ -0,0 +1,123 @@
+package country
+
+import (
+	"testing"
+)
+
+type StringToYesNoCase struct {
+	s                 string
+	expectedValue     bool
+	expectedException string
+	expectedError     error
+}
+
+var stringToYesNoCases = []StringToYesNoCase{
+	StringToYesNoCase{
+		s:             "yes",
+		expectedValue: true,
+	},
+	StringToYesNoCase{
+		s:                 "no, except in cases where the other country recognizes dual citizenship",
+		expectedValue:     false,
+		expectedException: "except in cases where the other country recognizes dual citizenship",
+	},
+	StringToYesNoCase{
+		s:                 "yes, but requires prior permission from the government",
+		expectedValue:     true,
+		expectedException: "but requires prior permission from the government",
+	},
+	// see Australia
+	StringToYesNoCase{
+		s:             "at least one parent must be a citizen",
+		expectedError: NoValueErr,
+	},
+}
+
+func TestStringToYesNo(t *testing.T) {
+	for testIndex, c := range stringToYesNoCases {
+		o, err := stringToYesNo(c.s)
+		if err != c.expectedError {
+			t.Error("stringToYesNo error, testIndex: ", testIndex, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		value, _ := o.Get("value")
+		if value != c.expectedValue {
+			t.Error("stringToYesNo value, testIndex: ", testIndex, value)
+		}
+		exception, exists := o.Get("exception")
+		if len(c.expectedException) == 0 && exists {
+			t.Error("stringToYesNo unexpected exception, testIndex: ", testIndex, exception)
+		}
+		if len(c.expectedException) > 0 && exception != c.expectedException {
+			t.Error("stringToYesNo exception, testIndex: ", testIndex, exception)
+		}
+	}
+}
+
+type StringToResidencyCase struct {
+	s             string
+	expectedYears float64
+	expectedError error
+}
+
+var stringToResidencyCases = []StringToResidencyCase{
+	StringToResidencyCase{
+		s:             "5 years",
+		expectedYears: 5,
+	},
+	StringToResidencyCase{
+		s:             "7 years; 3 years if married to a citizen",
+		expectedYears: 7,
+	},
+	// see Botswana
+	StringToResidencyCase{
+		s:             "10 out of the previous 12 years",
+		expectedYears: 10,
+	},
+	StringToResidencyCase{
+		s:             "18 months",
+		expectedYears: 1.5,
+	},
+	// years and months are added together
+	StringToResidencyCase{
+		s:             "5 years, 6 months",
+		expectedYears: 5.5,
+	},
+	StringToResidencyCase{
+		s:             "1 year and 6 months; 6 months if married to a citizen",
+		expectedYears: 1.5,
+	},
+	// only the first duration of alternatives
+	StringToResidencyCase{
+		s:             "5 years or 3 years if married to a citizen",
+		expectedYears: 5,
+	},
+	StringToResidencyCase{
+		s:             "unknown",
+		expectedError: NoValueErr,
+	},
+}
+
+func TestStringToResidency(t *testing.T) {
+	for testIndex, c := range stringToResidencyCases {
+		o, err := stringToResidency(c.s)
+		if err != c.expectedError {
+			t.Error("stringToResidency error, testIndex: ", testIndex, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		years, _ := o.Get("years")
+		if years != c.expectedYears {
+			t.Error("stringToResidency years, testIndex: ", testIndex, years)
+		}
+		text, _ := o.Get("text")
+		if text != c.s {
+			t.Error("stringToResidency text, testIndex: ", testIndex, text)
+		}
+	}
+}
@@ -0,0 +1,551 @@
+package country
+
+import (
+	"orderedmap"
+	"sort"
+	"strings"
+)
+
+// Codes are the identifiers for a factbook entity.
+// Gec is the two letter code used in the factbook filenames, eg ez for
+// Czechia, which is the only id that stays the same when a country is
+// renamed. Entities without an ISO 3166 code, eg oceans, have empty Iso
+// values.
+type Codes struct {
+	Gec        string
+	IsoAlpha2  string
+	IsoAlpha3  string
+	IsoNumeric string
+	Name       string
+}
+
+// Some entities share an ISO code with other entities, eg the islands of
+// UM and TF, and Gaza Strip and West Bank share PS.
+// Kosovo uses the user assigned code XK which has no numeric code.
+var codesList = []Codes{
+	Codes{"aa", "AW", "ABW", "533", "Aruba"},
+	Codes{"ac", "AG", "ATG", "028", "Antigua and Barbuda"},
+	Codes{"ae", "AE", "ARE", "784", "United Arab Emirates"},
+	Codes{"af", "AF", "AFG", "004", "Afghanistan"},
+	Codes{"ag", "DZ", "DZA", "012", "Algeria"},
+	Codes{"aj", "AZ", "AZE", "031", "Azerbaijan"},
+	Codes{"al", "AL", "ALB", "008", "Albania"},
+	Codes{"am", "AM", "ARM", "051", "Armenia"},
+	Codes{"an", "AD", "AND", "020", "Andorra"},
+	Codes{"ao", "AO", "AGO", "024", "Angola"},
+	Codes{"aq", "AS", "ASM", "016", "American Samoa"},
+	Codes{"ar", "AR", "ARG", "032", "Argentina"},
+	Codes{"as", "AU", "AUS", "036", "Australia"},
+	Codes{"at", "", "", "", "Ashmore and Cartier Islands"},
+	Codes{"au", "AT", "AUT", "040", "Austria"},
+	Codes{"av", "AI", "AIA", "660", "Anguilla"},
+	Codes{"ax", "", "", "", "Akrotiri"},
+	Codes{"ay", "AQ", "ATA", "010", "Antarctica"},
+	Codes{"ba", "BH", "BHR", "048", "Bahrain"},
+	Codes{"bb", "BB", "BRB", "052", "Barbados"},
+	Codes{"bc", "BW", "BWA", "072", "Botswana"},
+	Codes{"bd", "BM", "BMU", "060", "Bermuda"},
+	Codes{"be", "BE", "BEL", "056", "Belgium"},
+	Codes{"bf", "BS", "BHS", "044", "Bahamas, The"},
+	Codes{"bg", "BD", "BGD", "050", "Bangladesh"},
+	Codes{"bh", "BZ", "BLZ", "084", "Belize"},
+	Codes{"bk", "BA", "BIH", "070", "Bosnia and Herzegovina"},
+	Codes{"bl", "BO", "BOL", "068", "Bolivia"},
+	Codes{"bm", "MM", "MMR", "104", "Burma"},
+	Codes{"bn", "BJ", "BEN", "204", "Benin"},
+	Codes{"bo", "BY", "BLR", "112", "Belarus"},
+	Codes{"bp", "SB", "SLB", "090", "Solomon Islands"},
+	Codes{"bq", "UM", "UMI", "581", "Navassa Island"},
+	Codes{"br", "BR", "BRA", "076", "Brazil"},
+	Codes{"bs", "TF", "ATF", "260", "Bassas da India"},
+	Codes{"bt", "BT", "BTN", "064", "Bhutan"},
+	Codes{"bu", "BG", "BGR", "100", "Bulgaria"},
+	Codes{"bv", "BV", "BVT", "074", "Bouvet Island"},
+	Codes{"bx", "BN", "BRN", "096", "Brunei"},
+	Codes{"by", "BI", "BDI", "108", "Burundi"},
+	Codes{"ca", "CA", "CAN", "124", "Canada"},
+	Codes{"cb", "KH", "KHM", "116", "Cambodia"},
+	Codes{"cd", "TD", "TCD", "148", "Chad"},
+	Codes{"ce", "LK", "LKA", "144", "Sri Lanka"},
+	Codes{"cf", "CG", "COG", "178", "Congo, Republic of the"},
+	Codes{"cg", "CD", "COD", "180", "Congo, Democratic Republic of the"},
+	Codes{"ch", "CN", "CHN", "156", "China"},
+	Codes{"ci", "CL", "CHL", "152", "Chile"},
+	Codes{"cj", "KY", "CYM", "136", "Cayman Islands"},
+	Codes{"ck", "CC", "CCK", "166", "Cocos (Keeling) Islands"},
+	Codes{"cm", "CM", "CMR", "120", "Cameroon"},
+	Codes{"cn", "KM", "COM", "174", "Comoros"},
+	Codes{"co", "CO", "COL", "170", "Colombia"},
+	Codes{"cq", "MP", "MNP", "580", "Northern Mariana Islands"},
+	Codes{"cr", "", "", "", "Coral Sea Islands"},
+	Codes{"cs", "CR", "CRI", "188", "Costa Rica"},
+	Codes{"ct", "CF", "CAF", "140", "Central African Republic"},
+	Codes{"cu", "CU", "CUB", "192", "Cuba"},
+	Codes{"cv", "CV", "CPV", "132", "Cabo Verde"},
+	Codes{"cw", "CK", "COK", "184", "Cook Islands"},
+	Codes{"cy", "CY", "CYP", "196", "Cyprus"},
+	Codes{"da", "DK", "DNK", "208", "Denmark"},
+	Codes{"dj", "DJ", "DJI", "262", "Djibouti"},
+	Codes{"do", "DM", "DMA", "212", "Dominica"},
+	Codes{"dq", "UM", "UMI", "581", "Jarvis Island"},
+	Codes{"dr", "DO", "DOM", "214", "Dominican Republic"},
+	Codes{"dx", "", "", "", "Dhekelia"},
+	Codes{"ec", "EC", "ECU", "218", "Ecuador"},
+	Codes{"ee", "", "", "", "European Union"},
+	Codes{"eg", "EG", "EGY", "818", "Egypt"},
+	Codes{"ei", "IE", "IRL", "372", "Ireland"},
+	Codes{"ek", "GQ", "GNQ", "226", "Equatorial Guinea"},
+	Codes{"en", "EE", "EST", "233", "Estonia"},
+	Codes{"er", "ER", "ERI", "232", "Eritrea"},
+	Codes{"es", "SV", "SLV", "222", "El Salvador"},
+	Codes{"et", "ET", "ETH", "231", "Ethiopia"},
+	Codes{"eu", "TF", "ATF", "260", "Europa Island"},
+	Codes{"ez", "CZ", "CZE", "203", "Czechia"},
+	Codes{"fg", "GF", "GUF", "254", "French Guiana"},
+	Codes{"fi", "FI", "FIN", "246", "Finland"},
+	Codes{"fj", "FJ", "FJI", "242", "Fiji"},
+	Codes{"fk", "FK", "FLK", "238", "Falkland Islands (Islas Malvinas)"},
+	Codes{"fm", "FM", "FSM", "583", "Micronesia, Federated States of"},
+	Codes{"fo", "FO", "FRO", "234", "Faroe Islands"},
+	Codes{"fp", "PF", "PYF", "258", "French Polynesia"},
+	Codes{"fq", "UM", "UMI", "581", "Baker Island"},
+	Codes{"fr", "FR", "FRA", "250", "France"},
+	Codes{"fs", "TF", "ATF", "260", "French Southern and Antarctic Lands"},
+	Codes{"ga", "GM", "GMB", "270", "Gambia, The"},
+	Codes{"gb", "GA", "GAB", "266", "Gabon"},
+	Codes{"gg", "GE", "GEO", "268", "Georgia"},
+	Codes{"gh", "GH", "GHA", "288", "Ghana"},
+	Codes{"gi", "GI", "GIB", "292", "Gibraltar"},
+	Codes{"gj", "GD", "GRD", "308", "Grenada"},
+	Codes{"gk", "GG", "GGY", "831", "Guernsey"},
+	Codes{"gl", "GL", "GRL", "304", "Greenland"},
+	Codes{"gm", "DE", "DEU", "276", "Germany"},
+	Codes{"go", "TF", "ATF", "260", "Glorioso Islands"},
+	Codes{"gp", "GP", "GLP", "312", "Guadeloupe"},
+	Codes{"gq", "GU", "GUM", "316", "Guam"},
+	Codes{"gr", "GR", "GRC", "300", "Greece"},
+	Codes{"gt", "GT", "GTM", "320", "Guatemala"},
+	Codes{"gv", "GN", "GIN", "324", "Guinea"},
+	Codes{"gy", "GY", "GUY", "328", "Guyana"},
+	Codes{"gz", "PS", "PSE", "275", "Gaza Strip"},
+	Codes{"ha", "HT", "HTI", "332", "Haiti"},
+	Codes{"hk", "HK", "HKG", "344", "Hong Kong"},
+	Codes{"hm", "HM", "HMD", "334", "Heard Island and McDonald Islands"},
+	Codes{"ho", "HN", "HND", "340", "Honduras"},
+	Codes{"hq", "UM", "UMI", "581", "Howland Island"},
+	Codes{"hr", "HR", "HRV", "191", "Croatia"},
+	Codes{"hu", "HU", "HUN", "348", "Hungary"},
+	Codes{"ic", "IS", "ISL", "352", "Iceland"},
+	Codes{"id", "ID", "IDN", "360", "Indonesia"},
+	Codes{"im", "IM", "IMN", "833", "Isle of Man"},
+	Codes{"in", "IN", "IND", "356", "India"},
+	Codes{"io", "IO", "IOT", "086", "British Indian Ocean Territory"},
+	Codes{"ip", "", "", "", "Clipperton Island"},
+	Codes{"ir", "IR", "IRN", "364", "Iran"},
+	Codes{"is", "IL", "ISR", "376", "Israel"},
+	Codes{"it", "IT", "ITA", "380", "Italy"},
+	Codes{"iv", "CI", "CIV", "384", "Cote d'Ivoire"},
+	Codes{"iz", "IQ", "IRQ", "368", "Iraq"},
+	Codes{"ja", "JP", "JPN", "392", "Japan"},
+	Codes{"je", "JE", "JEY", "832", "Jersey"},
+	Codes{"jm", "JM", "JAM", "388", "Jamaica"},
+	Codes{"jn", "SJ", "SJM", "744", "Jan Mayen"},
+	Codes{"jo", "JO", "JOR", "400", "Jordan"},
+	Codes{"jq", "UM", "UMI", "581", "Johnston Atoll"},
+	Codes{"ju", "TF", "ATF", "260", "Juan de Nova Island"},
+	Codes{"ke", "KE", "KEN", "404", "Kenya"},
+	Codes{"kg", "KG", "KGZ", "417", "Kyrgyzstan"},
+	Codes{"kn", "KP", "PRK", "408", "Korea, North"},
+	Codes{"kq", "UM", "UMI", "581", "Kingman Reef"},
+	Codes{"kr", "KI", "KIR", "296", "Kiribati"},
+	Codes{"ks", "KR", "KOR", "410", "Korea, South"},
+	Codes{"kt", "CX", "CXR", "162", "Christmas Island"},
+	Codes{"ku", "KW", "KWT", "414", "Kuwait"},
+	Codes{"kv", "XK", "XKX", "", "Kosovo"},
+	Codes{"kz", "KZ", "KAZ", "398", "Kazakhstan"},
+	Codes{"la", "LA", "LAO", "418", "Laos"},
+	Codes{"le", "LB", "LBN", "422", "Lebanon"},
+	Codes{"lg", "LV", "LVA", "428", "Latvia"},
+	Codes{"lh", "LT", "LTU", "440", "Lithuania"},
+	Codes{"li", "LR", "LBR", "430", "Liberia"},
+	Codes{"lo", "SK", "SVK", "703", "Slovakia"},
+	Codes{"lq", "UM", "UMI", "581", "Palmyra Atoll"},
+	Codes{"ls", "LI", "LIE", "438", "Liechtenstein"},
+	Codes{"lt", "LS", "LSO", "426", "Lesotho"},
+	Codes{"lu", "LU", "LUX", "442", "Luxembourg"},
+	Codes{"ly", "LY", "LBY", "434", "Libya"},
+	Codes{"ma", "MG", "MDG", "450", "Madagascar"},
+	Codes{"mb", "MQ", "MTQ", "474", "Martinique"},
+	Codes{"mc", "MO", "MAC", "446", "Macau"},
+	Codes{"md", "MD", "MDA", "498", "Moldova"},
+	Codes{"mf", "YT", "MYT", "175", "Mayotte"},
+	Codes{"mg", "MN", "MNG", "496", "Mongolia"},
+	Codes{"mh", "MS", "MSR", "500", "Montserrat"},
+	Codes{"mi", "MW", "MWI", "454", "Malawi"},
+	Codes{"mj", "ME", "MNE", "499", "Montenegro"},
+	Codes{"mk", "MK", "MKD", "807", "North Macedonia"},
+	Codes{"ml", "ML", "MLI", "466", "Mali"},
+	Codes{"mn", "MC", "MCO", "492", "Monaco"},
+	Codes{"mo", "MA", "MAR", "504", "Morocco"},
+	Codes{"mp", "MU", "MUS", "480", "Mauritius"},
+	Codes{"mq", "UM", "UMI", "581", "Midway Islands"},
+	Codes{"mr", "MR", "MRT", "478", "Mauritania"},
+	Codes{"mt", "MT", "MLT", "470", "Malta"},
+	Codes{"mu", "OM", "OMN", "512", "Oman"},
+	Codes{"mv", "MV", "MDV", "462", "Maldives"},
+	Codes{"mx", "MX", "MEX", "484", "Mexico"},
+	Codes{"my", "MY", "MYS", "458", "Malaysia"},
+	Codes{"mz", "MZ", "MOZ", "508", "Mozambique"},
+	Codes{"nc", "NC", "NCL", "540", "New Caledonia"},
+	Codes{"ne", "NU", "NIU", "570", "Niue"},
+	Codes{"nf", "NF", "NFK", "574", "Norfolk Island"},
+	Codes{"ng", "NE", "NER", "562", "Niger"},
+	Codes{"nh", "VU", "VUT", "548", "Vanuatu"},
+	Codes{"ni", "NG", "NGA", "566", "Nigeria"},
+	Codes{"nl", "NL", "NLD", "528", "Netherlands"},
+	Codes{"nn", "SX", "SXM", "534", "Sint Maarten"},
+	Codes{"no", "NO", "NOR", "578", "Norway"},
+	Codes{"np", "NP", "NPL", "524", "Nepal"},
+	Codes{"nr", "NR", "NRU", "520", "Nauru"},
+	Codes{"ns", "SR", "SUR", "740", "Suriname"},
+	Codes{"nt", "AN", "ANT", "530", "Netherlands Antilles"},
+	Codes{"nu", "NI", "NIC", "558", "Nicaragua"},
+	Codes{"nz", "NZ", "NZL", "554", "New Zealand"},
+	Codes{"od", "SS", "SSD", "728", "South Sudan"},
+	Codes{"oo", "", "", "", "Southern Ocean"},
+	Codes{"pa", "PY", "PRY", "600", "Paraguay"},
+	Codes{"pc", "PN", "PCN", "612", "Pitcairn Islands"},
+	Codes{"pe", "PE", "PER", "604", "Peru"},
+	Codes{"pf", "", "", "", "Paracel Islands"},
+	Codes{"pg", "", "", "", "Spratly Islands"},
+	Codes{"pk", "PK", "PAK", "586", "Pakistan"},
+	Codes{"pl", "PL", "POL", "616", "Poland"},
+	Codes{"pm", "PA", "PAN", "591", "Panama"},
+	Codes{"po", "PT", "PRT", "620", "Portugal"},
+	Codes{"pp", "PG", "PNG", "598", "Papua New Guinea"},
+	Codes{"ps", "PW", "PLW", "585", "Palau"},
+	Codes{"pu", "GW", "GNB", "624", "Guinea-Bissau"},
+	Codes{"qa", "QA", "QAT", "634", "Qatar"},
+	Codes{"rb", "RS", "SRB", "688", "Serbia"},
+	Codes{"re", "RE", "REU", "638", "Reunion"},
+	Codes{"ri", "RS", "SRB", "688", "Serbia"},
+	Codes{"rm", "MH", "MHL", "584", "Marshall Islands"},
+	Codes{"rn", "MF", "MAF", "663", "Saint Martin"},
+	Codes{"ro", "RO", "ROU", "642", "Romania"},
+	Codes{"rp", "PH", "PHL", "608", "Philippines"},
+	Codes{"rq", "PR", "PRI", "630", "Puerto Rico"},
+	Codes{"rs", "RU", "RUS", "643", "Russia"},
+	Codes{"rw", "RW", "RWA", "646", "Rwanda"},
+	Codes{"sa", "SA", "SAU", "682", "Saudi Arabia"},
+	Codes{"sb", "PM", "SPM", "666", "Saint Pierre and Miquelon"},
+	Codes{"sc", "KN", "KNA", "659", "Saint Kitts and Nevis"},
+	Codes{"se", "SC", "SYC", "690", "Seychelles"},
+	Codes{"sf", "ZA", "ZAF", "710", "South Africa"},
+	Codes{"sg", "SN", "SEN", "686", "Senegal"},
+	Codes{"sh", "SH", "SHN", "654", "Saint Helena, Ascension, and Tristan da Cunha"},
+	Codes{"si", "SI", "SVN", "705", "Slovenia"},
+	Codes{"sl", "SL", "SLE", "694", "Sierra Leone"},
+	Codes{"sm", "SM", "SMR", "674", "San Marino"},
+	Codes{"sn", "SG", "SGP", "702", "Singapore"},
+	Codes{"so", "SO", "SOM", "706", "Somalia"},
+	Codes{"sp", "ES", "ESP", "724", "Spain"},
+	Codes{"st", "LC", "LCA", "662", "Saint Lucia"},
+	Codes{"su", "SD", "SDN", "729", "Sudan"},
+	Codes{"sv", "SJ", "SJM", "744", "Svalbard"},
+	Codes{"sw", "SE", "SWE", "752", "Sweden"},
+	Codes{"sx", "GS", "SGS", "239", "South Georgia and South Sandwich Islands"},
+	Codes{"sy", "SY", "SYR", "760", "Syria"},
+	Codes{"sz", "CH", "CHE", "756", "Switzerland"},
+	Codes{"tb", "BL", "BLM", "652", "Saint Barthelemy"},
+	Codes{"td", "TT", "TTO", "780", "Trinidad and Tobago"},
+	Codes{"te", "TF", "ATF", "260", "Tromelin Island"},
+	Codes{"th", "TH", "THA", "764", "Thailand"},
+	Codes{"ti", "TJ", "TJK", "762", "Tajikistan"},
+	Codes{"tk", "TC", "TCA", "796", "Turks and Caicos Islands"},
+	Codes{"tl", "TK", "TKL", "772", "Tokelau"},
+	Codes{"tn", "TO", "TON", "776", "Tonga"},
+	Codes{"to", "TG", "TGO", "768", "Togo"},
+	Codes{"tp", "ST", "STP", "678", "Sao Tome and Principe"},
+	Codes{"ts", "TN", "TUN", "788", "Tunisia"},
+	Codes{"tt", "TL", "TLS", "626", "Timor-Leste"},
+	Codes{"tu", "TR", "TUR", "792", "Turkey"},
+	Codes{"tv", "TV", "TUV", "798", "Tuvalu"},
+	Codes{"tw", "TW", "TWN", "158", "Taiwan"},
+	Codes{"tx", "TM", "TKM", "795", "Turkmenistan"},
+	Codes{"tz", "TZ", "TZA", "834", "Tanzania"},
+	Codes{"uc", "CW", "CUW", "531", "Curacao"},
+	Codes{"ug", "UG", "UGA", "800", "Uganda"},
+	Codes{"uk", "GB", "GBR", "826", "United Kingdom"},
+	Codes{"um", "UM", "UMI", "581", "United States Pacific Island Wildlife Refuges"},
+	Codes{"up", "UA", "UKR", "804", "Ukraine"},
+	Codes{"us", "US", "USA", "840", "United States"},
+	Codes{"uv", "BF", "BFA", "854", "Burkina Faso"},
+	Codes{"uy", "UY", "URY", "858", "Uruguay"},
+	Codes{"uz", "UZ", "UZB", "860", "Uzbekistan"},
+	Codes{"vc", "VC", "VCT", "670", "Saint Vincent and the Grenadines"},
+	Codes{"ve", "VE", "VEN", "862", "Venezuela"},
+	Codes{"vi", "VG", "VGB", "092", "British Virgin Islands"},
+	Codes{"vm", "VN", "VNM", "704", "Vietnam"},
+	Codes{"vq", "VI", "VIR", "850", "Virgin Islands"},
+	Codes{"vt", "VA", "VAT", "336", "Holy See (Vatican City)"},
+	Codes{"wa", "NA", "NAM", "516", "Namibia"},
+	Codes{"we", "PS", "PSE", "275", "West Bank"},
+	Codes{"wf", "WF", "WLF", "876", "Wallis and Futuna"},
+	Codes{"wi", "EH", "ESH", "732", "Western Sahara"},
+	Codes{"wq", "UM", "UMI", "581", "Wake Island"},
+	Codes{"ws", "WS", "WSM", "882", "Samoa"},
+	Codes{"wz", "SZ", "SWZ", "748", "Eswatini"},
+	Codes{"xo", "", "", "", "Indian Ocean"},
+	Codes{"xq", "", "", "", "Arctic Ocean"},
+	Codes{"xx", "", "", "", "World"},
+	Codes{"yi", "CS", "SCG", "891", "Serbia and Montenegro"},
+	Codes{"ym", "YE", "YEM", "887", "Yemen"},
+	Codes{"za", "ZM", "ZMB", "894", "Zambia"},
+	Codes{"zh", "", "", "", "Atlantic Ocean"},
+	Codes{"zi", "ZW", "ZWE", "716", "Zimbabwe"},
+	Codes{"zn", "", "", "", "Pacific Ocean"},
+}
+
+// Other names used for countries in the text of the factbook
+var countryNameAliases = map[string]string{
+	"North Korea":                           "kn",
+	"South Korea":                           "ks",
+	"The Bahamas":                           "bf",
+	"Bahamas":                               "bf",
+	"The Gambia":                            "ga",
+	"Gambia":                                "ga",
+	"Republic of the Congo":                 "cf",
+	"Democratic Republic of the Congo":      "cg",
+	"DRC":                                   "cg",
+	"Micronesia":                            "fm",
+	"Federated States of Micronesia":        "fm",
+	"Holy See":                              "vt",
+	"Falkland Islands":                      "fk",
+	"Islas Malvinas":                        "fk",
+	"Saint Helena":                          "sh",
+	"Cocos Islands":                         "ck",
+	"UK":                                    "uk",
+	"US":                                    "us",
+	"Czech Republic":                        "ez",
+	"Swaziland":                             "wz",
+	"Macedonia":                             "mk",
+	"Former Yugoslav Republic of Macedonia": "mk",
+	"East Timor":                            "tt",
+	"Cape Verde":                            "cv",
+	"Myanmar":                               "bm",
+	"Turkiye":                               "tu",
+	"UAE":                                   "ae",
+}
+
+// Historical GEC codes which were later replaced by another code for the
+// same entity, eg Serbia was rb before it became ri. They are kept so older
+// pages are still found, but are not separate entities.
+var historicalGecs = map[string]string{
+	"rb": "ri",
+}
+
+var codesByGec = map[string]Codes{}
+var entitiesPerIsoCode = map[string]int{}
+var gecByCountryName = map[string]string{}
+var countryNamesLongestFirst = []string{}
+
+func init() {
+	for _, c := range codesList {
+		codesByGec[c.Gec] = c
+		if c.IsHistorical() {
+			continue
+		}
+		if c.IsoAlpha2 != "" {
+			entitiesPerIsoCode[c.IsoAlpha2] = entitiesPerIsoCode[c.IsoAlpha2] + 1
+		}
+		// the world is not a counterparty
+		if c.Gec == "xx" {
+			continue
+		}
+		gecByCountryName[c.Name] = c.Gec
+	}
+	for name, gec := range countryNameAliases {
+		gecByCountryName[name] = gec
+	}
+	for name, _ := range gecByCountryName {
+		countryNamesLongestFirst = append(countryNamesLongestFirst, name)
+	}
+	sort.Slice(countryNamesLongestFirst, func(i, j int) bool {
+		a := countryNamesLongestFirst[i]
+		b := countryNamesLongestFirst[j]
+		if len(a) == len(b) {
+			return a < b
+		}
+		return len(a) > len(b)
+	})
+}
+
+// Returns true if the GEC code was replaced by a later code for the same
+// entity, eg rb for Serbia
+func (c Codes) IsHistorical() bool {
+	_, exists := historicalGecs[c.Gec]
+	return exists
+}
+
+// Returns true if more than one entity has this ISO code, eg Wake Island.
+// Historical GEC codes are not separate entities so Serbia does not share
+// RS with itself.
+func (c Codes) SharesIsoCode() bool {
+	return entitiesPerIsoCode[c.IsoAlpha2] > 1
+}
+
+// Returns the codes for a factbook filename, eg ez.html or the full scraped
+// filename ending in ez.html
+func CodesForFilename(f string) (Codes, bool) {
+	f = strings.ToLower(f)
+	if len(f) < 7 || !endsWith(f, ".html") {
+		return Codes{}, false
+	}
+	gec := f[len(f)-7 : len(f)-5]
+	c, exists := codesByGec[gec]
+	return c, exists
+}
+
+func (c Codes) toMap() *orderedmap.OrderedMap {
+	o := orderedmap.New()
+	o.Set("gec", c.Gec)
+	if c.IsoAlpha2 != "" {
+		o.Set("iso_alpha2", c.IsoAlpha2)
+	}
+	if c.IsoAlpha3 != "" {
+		o.Set("iso_alpha3", c.IsoAlpha3)
+	}
+	if c.IsoNumeric != "" {
+		o.Set("iso_numeric", c.IsoNumeric)
+	}
+	return o
+}
+
+func isLetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func isCapitalised(word string) bool {
+	return len(word) > 0 && word[0] >= 'A' && word[0] <= 'Z'
+}
+
+// Returns the word ending one space before i and where it starts, or an
+// empty string if there is no word directly before i
+func wordBefore(s string, i int) (string, int) {
+	if i < 2 || s[i-1] != ' ' || !isLetter(s[i-2]) {
+		return "", i
+	}
+	start := i - 1
+	for start > 0 && isLetter(s[start-1]) {
+		start = start - 1
+	}
+	return s[start : i-1], start
+}
+
+// Returns the word starting one space after end, or an empty string if
+// there is no word directly after end
+func wordAfter(s string, end int) string {
+	if end+1 >= len(s) || s[end] != ' ' || !isLetter(s[end+1]) {
+		return ""
+	}
+	wordEnd := end + 1
+	for wordEnd < len(s) && isLetter(s[wordEnd]) {
+		wordEnd = wordEnd + 1
+	}
+	return s[end+1 : wordEnd]
+}
+
+// Returns true if the word starting at i is the first word of a sentence
+// or clause, where a capital letter does not mean a proper noun
+func startsSentence(s string, i int) bool {
+	before := strings.TrimRight(s[0:i], " ")
+	return len(before) == 0 || strings.ContainsAny(before[len(before)-1:len(before)], ".;:(\n")
+}
+
+// Returns true if the name at s[i:end] is part of a longer proper noun, eg
+// the Niger River, New Jersey or the Gulf of Guinea, rather than the
+// country. Capitalised words at the start of a sentence, eg "Both India and
+// Pakistan", are not part of the name.
+func isInProperNoun(s string, i, end int) bool {
+	if isCapitalised(wordAfter(s, end)) {
+		return true
+	}
+	before, beforeStart := wordBefore(s, i)
+	if before == "the" {
+		before, beforeStart = wordBefore(s, beforeStart)
+		if before != "of" {
+			return false
+		}
+	}
+	if before == "of" {
+		before, beforeStart = wordBefore(s, beforeStart)
+	}
+	return isCapitalised(before) && !startsSentence(s, beforeStart)
+}
+
+// Returns the GEC codes of the countries named in s in the order they first
+// appear. Longer names are matched first so Niger is not found in Nigeria
+// and Guinea is not found in Papua New Guinea. Names must be whole words
+// outside a longer proper noun, so US is not found in USSR, US2 or US$ and
+// Niger is not found in the Niger River.
+func countriesInString(s string) []string {
+	positions := map[string]int{}
+	for _, name := range countryNamesLongestFirst {
+		start := 0
+		for {
+			i := strings.Index(s[start:len(s)], name)
+			if i == -1 {
+				break
+			}
+			i = i + start
+			end := i + len(name)
+			start = end
+			// only match whole words
+			if i > 0 && (isLetter(s[i-1]) || isDigit(s[i-1])) {
+				continue
+			}
+			if end < len(s) && (isLetter(s[end]) || isDigit(s[end]) || s[end] == '$') {
+				continue
+			}
+			if isInProperNoun(s, i, end) {
+				continue
+			}
+			gec := gecByCountryName[name]
+			position, exists := positions[gec]
+			if !exists || i < position {
+				positions[gec] = i
+			}
+			// blank the match so shorter names cannot match inside it
+			s = s[0:i] + strings.Repeat(" ", len(name)) + s[end:len(s)]
+		}
+	}
+	gecs := []string{}
+	for gec, _ := range positions {
+		gecs = append(gecs, gec)
+	}
+	sort.Slice(gecs, func(i, j int) bool {
+		return positions[gecs[i]] < positions[gecs[j]]
+	})
+	return gecs
+}
+
+// Returns the codes for a country name as written in the factbook,
+// eg "Czechia", "Czech Republic" or "Korea, South"
+func CodesForCountryName(name string) (Codes, bool) {
+	name = strings.TrimSpace(name)
+	gec, exists := gecByCountryName[name]
+	if !exists {
+		// names with extra words, eg "the US"
+		gecs := countriesInString(name)
+		if len(gecs) != 1 {
+			return Codes{}, false
+		}
+		gec = gecs[0]
+	}
+	c, exists := codesByGec[gec]
+	return c, exists
+}
@@ -0,0 +1,221 @@
+package country
+
+import (
+	"orderedmap"
+	"testing"
+)
+
+type CodesForFilenameCase struct {
+	f                  string
+	expectedExists     bool
+	expectedGec        string
+	expectedIsoAlpha2  string
+	expectedIsoAlpha3  string
+	expectedIsoNumeric string
+}
+
+var codesForFilenameCases = []CodesForFilenameCase{
+	// short filename
+	CodesForFilenameCase{
+		f:                  "ez.html",
+		expectedExists:     true,
+		expectedGec:        "ez",
+		expectedIsoAlpha2:  "CZ",
+		expectedIsoAlpha3:  "CZE",
+		expectedIsoNumeric: "203",
+	},
+	// full scraped filename
+	CodesForFilenameCase{
+		f:                  "/pages/2017-01-02/https%3A%2F%2Fwww.cia.gov%2Flibrary%2Fpublications%2Fthe-world-factbook%2Fgeos%2Fwz.html",
+		expectedExists:     true,
+		expectedGec:        "wz",
+		expectedIsoAlpha2:  "SZ",
+		expectedIsoAlpha3:  "SWZ",
+		expectedIsoNumeric: "748",
+	},
+	// no iso code
+	CodesForFilenameCase{
+		f:              "xx.html",
+		expectedExists: true,
+		expectedGec:    "xx",
+	},
+	// unknown
+	CodesForFilenameCase{
+		f:              "zz.html",
+		expectedExists: false,
+	},
+}
+
+func TestCodesForFilename(t *testing.T) {
+	for testIndex, c := range codesForFilenameCases {
+		codes, exists := CodesForFilename(c.f)
+		if exists != c.expectedExists {
+			t.Error("CodesForFilename exists, testIndex: ", testIndex)
+			continue
+		}
+		if !exists {
+			continue
+		}
+		if codes.Gec != c.expectedGec {
+			t.Error("CodesForFilename gec, testIndex: ", testIndex, codes.Gec)
+		}
+		if codes.IsoAlpha2 != c.expectedIsoAlpha2 {
+			t.Error("CodesForFilename iso alpha2, testIndex: ", testIndex, codes.IsoAlpha2)
+		}
+		if codes.IsoAlpha3 != c.expectedIsoAlpha3 {
+			t.Error("CodesForFilename iso alpha3, testIndex: ", testIndex, codes.IsoAlpha3)
+		}
+		if codes.IsoNumeric != c.expectedIsoNumeric {
+			t.Error("CodesForFilename iso numeric, testIndex: ", testIndex, codes.IsoNumeric)
+		}
+	}
+}
+
+func TestCodesAreUnique(t *testing.T) {
+	seen := map[string]bool{}
+	for _, c := range codesList {
+		if seen[c.Gec] {
+			t.Error("duplicate gec code", c.Gec)
+		}
+		seen[c.Gec] = true
+		if c.IsoAlpha2 != "" && (len(c.IsoAlpha2) != 2 || len(c.IsoAlpha3) != 3) {
+			t.Error("invalid iso code", c.Gec, c.IsoAlpha2, c.IsoAlpha3)
+		}
+	}
+	wake, _ := CodesForFilename("wq.html")
+	if !wake.SharesIsoCode() {
+		t.Error("wake island should share iso code UM")
+	}
+	czechia, _ := CodesForFilename("ez.html")
+	if czechia.SharesIsoCode() {
+		t.Error("czechia should not share iso code")
+	}
+}
+
+func TestHistoricalGecCodes(t *testing.T) {
+	for _, f := range []string{"rb.html", "ri.html"} {
+		serbia, exists := CodesForFilename(f)
+		if !exists || serbia.IsoAlpha2 != "RS" || serbia.IsoAlpha3 != "SRB" {
+			t.Error("serbia codes", f, serbia)
+		}
+		if serbia.SharesIsoCode() {
+			t.Error("serbia should not share iso code with its historical gec", f)
+		}
+	}
+	rb, _ := CodesForFilename("rb.html")
+	if !rb.IsHistorical() {
+		t.Error("rb should be historical")
+	}
+	ri, _ := CodesForFilename("ri.html")
+	if ri.IsHistorical() {
+		t.Error("ri should not be historical")
+	}
+	gecs := countriesInString("Serbia and Kosovo")
+	if len(gecs) != 2 || gecs[0] != "ri" {
+		t.Error("serbia should be named by its current gec", gecs)
+	}
+}
+
+type CountriesInStringCase struct {
+	s        string
+	expected []string
+}
+
+var countriesInStringCases = []CountriesInStringCase{
+	// longer names are matched before shorter names within them
+	CountriesInStringCase{
+		s:        "Nigeria and Niger dispute the boundary with Chad",
+		expected: []string{"ni", "ng", "cd"},
+	},
+	CountriesInStringCase{
+		s:        "Equatorial Guinea and Papua New Guinea",
+		expected: []string{"ek", "pp"},
+	},
+	// aliases and repeated names
+	CountriesInStringCase{
+		s:        "the UK and Argentina; Argentina claims the Falkland Islands",
+		expected: []string{"uk", "ar", "fk"},
+	},
+	// names which are not the country
+	CountriesInStringCase{
+		s:        "Benin and Nigeria dispute use of the Niger River; trade worth US$5 billion",
+		expected: []string{"bn", "ni"},
+	},
+	CountriesInStringCase{
+		s:        "piracy in the Gulf of Guinea off the coast of the USSR and US2 dollars",
+		expected: []string{},
+	},
+	CountriesInStringCase{
+		s:        "Nigeria claims islands in Lake Chad; drugs are shipped to New Jersey",
+		expected: []string{"ni"},
+	},
+	CountriesInStringCase{
+		s:        "Niger and the US",
+		expected: []string{"ng", "us"},
+	},
+	// capitalised words at the start of a sentence
+	CountriesInStringCase{
+		s:        "Both India and Pakistan claim Kashmir. Since Sudan",
+		expected: []string{"in", "pk", "su"},
+	},
+	// no countries
+	CountriesInStringCase{
+		s:        "none",
+		expected: []string{},
+	},
+}
+
+func TestCountriesInString(t *testing.T) {
+	for testIndex, c := range countriesInStringCases {
+		gecs := countriesInString(c.s)
+		if len(gecs) != len(c.expected) {
+			t.Error("countriesInString length, testIndex: ", testIndex, gecs)
+			continue
+		}
+		for i, gec := range gecs {
+			if gec != c.expected[i] {
+				t.Error("countriesInString gec, testIndex: ", testIndex, gecs)
+			}
+		}
+	}
+}
+
+func TestDisputes(t *testing.T) {
+	value := "China and India dispute the boundary in Aksai Chin; Japan and South Korea claim Liancourt Rocks; dispute over the maritime boundary with Colombia"
+	disputesInterface, err := disputes(value)
+	if err != nil {
+		t.Error("disputes error", err)
+		return
+	}
+	list := disputesInterface.([]*orderedmap.OrderedMap)
+	if len(list) != 3 {
+		t.Error("disputes length", len(list))
+		return
+	}
+	expectedCategories := []string{"boundary", "territorial", "maritime"}
+	for i, d := range list {
+		category, _ := d.Get("category")
+		if category != expectedCategories[i] {
+			t.Error("disputes category", i, category)
+		}
+	}
+	// the Niger River is not a dispute with Niger
+	river, err := disputes("Benin and Nigeria share the Niger River water")
+	if err != nil {
+		t.Error("disputes error", err)
+		return
+	}
+	riverDispute := river.([]*orderedmap.OrderedMap)[0]
+	riverCountries, _ := riverDispute.Get("countries")
+	if len(riverCountries.([]string)) != 2 || riverCountries.([]string)[1] != "ni" {
+		t.Error("disputes countries for Niger River", riverCountries)
+	}
+	riverCategory, _ := riverDispute.Get("category")
+	if riverCategory != "water" {
+		t.Error("disputes category for Niger River", riverCategory)
+	}
+	countries, _ := list[1].Get("countries")
+	if len(countries.([]string)) != 2 || countries.([]string)[1] != "ks" {
+		t.Error("disputes countries", countries)
+	}
+}
@@ -0,0 +1,233 @@
+package country
+
+import (
+	"regexp"
+	"strings"
+)
+
+var currencyCodeRe = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// ISO 4217 codes for currency names as written in the exchange rates field.
+// Recent pages include the code in parenthesis, eg euros (EUR), but older
+// pages only have the name.
+var currencyCodes = map[string]string{
+	"afghanis":                               "AFN",
+	"algerian dinars":                        "DZD",
+	"angolan kwanza":                         "AOA",
+	"argentine pesos":                        "ARS",
+	"armenian drams":                         "AMD",
+	"aruban guilders/florins":                "AWG",
+	"australian dollars":                     "AUD",
+	"azerbaijani manats":                     "AZN",
+	"bahamian dollars":                       "BSD",
+	"bahraini dinars":                        "BHD",
+	"baht":                                   "THB",
+	"balboas":                                "PAB",
+	"barbadian dollars":                      "BBD",
+	"belarusian rubles":                      "BYN",
+	"belizean dollars":                       "BZD",
+	"bermudian dollars":                      "BMD",
+	"birr":                                   "ETB",
+	"bolivianos":                             "BOB",
+	"botswana pula":                          "BWP",
+	"brazilian reals":                        "BRL",
+	"british pounds":                         "GBP",
+	"bruneian dollars":                       "BND",
+	"bulgarian leva":                         "BGN",
+	"burundi francs":                         "BIF",
+	"cambodian riels":                        "KHR",
+	"canadian dollars":                       "CAD",
+	"cape verdean escudos":                   "CVE",
+	"cayman islands dollars":                 "KYD",
+	"cedis":                                  "GHS",
+	"chilean pesos":                          "CLP",
+	"colombian pesos":                        "COP",
+	"comoran francs":                         "KMF",
+	"communaute financiere africaine francs": "XOF",
+	"congolese francs":                       "CDF",
+	"cooperation financiere en afrique centrale francs": "XAF",
+	"comptoirs francais du pacifique francs":            "XPF",
+	"convertible marks":                                 "BAM",
+	"cordobas":                                          "NIO",
+	"costa rican colones":                               "CRC",
+	"croatian kuna":                                     "HRK",
+	"cuban pesos":                                       "CUP",
+	"czech koruny":                                      "CZK",
+	"dalasi":                                            "GMD",
+	"danish kroner":                                     "DKK",
+	"djiboutian francs":                                 "DJF",
+	"dobras":                                            "STN",
+	"dominican pesos":                                   "DOP",
+	"east caribbean dollars":                            "XCD",
+	"egyptian pounds":                                   "EGP",
+	"euros":                                             "EUR",
+	"falkland pounds":                                   "FKP",
+	"fijian dollars":                                    "FJD",
+	"forint":                                            "HUF",
+	"gibraltar pounds":                                  "GIP",
+	"gourdes":                                           "HTG",
+	"guarani":                                           "PYG",
+	"guinean francs":                                    "GNF",
+	"guyanese dollars":                                  "GYD",
+	"hong kong dollars":                                 "HKD",
+	"hryvnia":                                           "UAH",
+	"icelandic kronur":                                  "ISK",
+	"indian rupees":                                     "INR",
+	"indonesian rupiah":                                 "IDR",
+	"iranian rials":                                     "IRR",
+	"iraqi dinars":                                      "IQD",
+	"israeli new shekels":                               "ILS",
+	"jamaican dollars":                                  "JMD",
+	"japanese yen":                                      "JPY",
+	"jordanian dinars":                                  "JOD",
+	"kenyan shillings":                                  "KES",
+	"kina":                                              "PGK",
+	"kuwaiti dinars":                                    "KWD",
+	"kyats":                                             "MMK",
+	"lari":                                              "GEL",
+	"lebanese pounds":                                   "LBP",
+	"lempiras":                                          "HNL",
+	"leones":                                            "SLL",
+	"liberian dollars":                                  "LRD",
+	"libyan dinars":                                     "LYD",
+	"maloti":                                            "LSL",
+	"malagasy ariary":                                   "MGA",
+	"malawian kwachas":                                  "MWK",
+	"malaysian ringgits":                                "MYR",
+	"mauritian rupees":                                  "MUR",
+	"mexican pesos":                                     "MXN",
+	"moldovan lei":                                      "MDL",
+	"moroccan dirhams":                                  "MAD",
+	"mozambican meticais":                               "MZN",
+	"nairas":                                            "NGN",
+	"namibian dollars":                                  "NAD",
+	"nepalese rupees":                                   "NPR",
+	"netherlands antillean guilders":                    "ANG",
+	"new taiwan dollars":                                "TWD",
+	"new zealand dollars":                               "NZD",
+	"ngultrum":                                          "BTN",
+	"north korean won":                                  "KPW",
+	"norwegian kroner":                                  "NOK",
+	"nuevo sol":                                         "PEN",
+	"omani rials":                                       "OMR",
+	"ouguiyas":                                          "MRU",
+	"pa'anga":                                           "TOP",
+	"pakistani rupees":                                  "PKR",
+	"patacas":                                           "MOP",
+	"philippine pesos":                                  "PHP",
+	"qatari rials":                                      "QAR",
+	"quetzales":                                         "GTQ",
+	"renminbi yuan":                                     "CNY",
+	"romanian lei":                                      "RON",
+	"rufiyaa":                                           "MVR",
+	"russian rubles":                                    "RUB",
+	"rwandan francs":                                    "RWF",
+	"saint helenian pounds":                             "SHP",
+	"saudi riyals":                                      "SAR",
+	"serbian dinars":                                    "RSD",
+	"seychelles rupees":                                 "SCR",
+	"singapore dollars":                                 "SGD",
+	"solomon islands dollars":                           "SBD",
+	"somali shillings":                                  "SOS",
+	"som":                                               "KGS",
+	"somoni":                                            "TJS",
+	"south african rand":                                "ZAR",
+	"south korean won":                                  "KRW",
+	"south sudanese pounds":                             "SSP",
+	"sri lankan rupees":                                 "LKR",
+	"sudanese pounds":                                   "SDG",
+	"surinamese dollars":                                "SRD",
+	"swedish kronor":                                    "SEK",
+	"swiss francs":                                      "CHF",
+	"syrian pounds":                                     "SYP",
+	"taka":                                              "BDT",
+	"tala":                                              "WST",
+	"tanzanian shillings":                               "TZS",
+	"tenge":                                             "KZT",
+	"trinidad and tobago dollars":                       "TTD",
+	"tugriks":                                           "MNT",
+	"tunisian dinars":                                   "TND",
+	"turkish liras":                                     "TRY",
+	"turkmenistani manat":                               "TMT",
+	"uae dirhams":                                       "AED",
+	"ugandan shillings":                                 "UGX",
+	"uruguayan pesos":                                   "UYU",
+	"us dollars":                                        "USD",
+	"uzbekistani soum":                                  "UZS",
+	"vatu":                                              "VUV",
+	"venezuelan bolivars":                               "VES",
+	"dong":                                              "VND",
+	"yemeni rials":                                      "YER",
+	"zambian kwacha":                                    "ZMW",
+	"zimbabwean dollars":                                "ZWL",
+	"emalangeni":                                        "SZL",
+	"lilangeni":                                         "SZL",
+	"denars":                                            "MKD",
+	"macedonian denars":                                 "MKD",
+	"lao kips":                                          "LAK",
+	"kips":                                              "LAK",
+	"eritrean nakfa":                                    "ERN",
+	"nakfa":                                             "ERN",
+	"polish zlotych":                                    "PLN",
+	"zlotych":                                           "PLN",
+	"zloty":                                             "PLN",
+}
+
+// datedCurrencyCode is a code used by a redenominated currency from
+// firstYear until the next code in the list
+type datedCurrencyCode struct {
+	code      string
+	firstYear int
+}
+
+// Currencies which were redenominated, keyed by the current code in
+// currencyCodes. Older pages use the same name for the old currency, so a
+// rate is labelled with the code in use in the year of the rate, eg a 2008
+// rate in Venezuelan bolivars is VEF.
+var redenominatedCurrencyCodes = map[string][]datedCurrencyCode{
+	"AZN": []datedCurrencyCode{datedCurrencyCode{"AZM", 0}, datedCurrencyCode{"AZN", 2006}},
+	"BYN": []datedCurrencyCode{datedCurrencyCode{"BYR", 0}, datedCurrencyCode{"BYN", 2016}},
+	"GHS": []datedCurrencyCode{datedCurrencyCode{"GHC", 0}, datedCurrencyCode{"GHS", 2007}},
+	"STN": []datedCurrencyCode{datedCurrencyCode{"STD", 0}, datedCurrencyCode{"STN", 2018}},
+	"TMT": []datedCurrencyCode{datedCurrencyCode{"TMM", 0}, datedCurrencyCode{"TMT", 2009}},
+	"TRY": []datedCurrencyCode{datedCurrencyCode{"TRL", 0}, datedCurrencyCode{"TRY", 2005}},
+	"VES": []datedCurrencyCode{datedCurrencyCode{"VEB", 0}, datedCurrencyCode{"VEF", 2008}, datedCurrencyCode{"VES", 2018}},
+	"ZMW": []datedCurrencyCode{datedCurrencyCode{"ZMK", 0}, datedCurrencyCode{"ZMW", 2013}},
+}
+
+// Returns the currency name and the ISO 4217 code in parenthesis from a
+// string such as "euros (EUR)". Names without a code, eg "Japanese yen",
+// are looked up with currencyCodeForName since the code depends on the year.
+func currencyFromString(s string) (string, string, bool) {
+	name, ps := removeParenthesis(s)
+	name = strings.TrimSpace(atLeastOneSpaceRe.ReplaceAllString(name, " "))
+	for _, p := range ps {
+		p = strings.TrimSpace(p)
+		if currencyCodeRe.MatchString(p) {
+			return name, p, true
+		}
+	}
+	return name, "", false
+}
+
+// Returns the ISO 4217 code for a currency name in the given year. A year
+// of 0 is an unknown year and returns the current code.
+func currencyCodeForName(name string, year int) (string, bool) {
+	key := strings.ToLower(name)
+	key = strings.TrimPrefix(key, "the ")
+	key = strings.Replace(key, "é", "e", -1)
+	key = strings.Replace(key, "è", "e", -1)
+	code, exists := currencyCodes[key]
+	if !exists {
+		return "", false
+	}
+	if year == 0 {
+		return code, true
+	}
+	for _, dated := range redenominatedCurrencyCodes[code] {
+		if year >= dated.firstYear {
+			code = dated.code
+		}
+	}
+	return code, true
+}
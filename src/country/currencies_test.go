@@ -0,0 +1,62 @@
+package country
+
+import (
+	"testing"
+)
+
+type CurrencyCodeForNameCase struct {
+	name         string
+	year         int
+	expectedCode string
+}
+
+var currencyCodeForNameCases = []CurrencyCodeForNameCase{
+	CurrencyCodeForNameCase{"Azerbaijani manats", 2005, "AZM"},
+	CurrencyCodeForNameCase{"Azerbaijani manats", 2006, "AZN"},
+	CurrencyCodeForNameCase{"Belarusian rubles", 2015, "BYR"},
+	CurrencyCodeForNameCase{"Belarusian rubles", 2016, "BYN"},
+	CurrencyCodeForNameCase{"cedis", 2006, "GHC"},
+	CurrencyCodeForNameCase{"cedis", 2007, "GHS"},
+	CurrencyCodeForNameCase{"dobras", 2017, "STD"},
+	CurrencyCodeForNameCase{"dobras", 2018, "STN"},
+	CurrencyCodeForNameCase{"Turkmenistani manat", 2008, "TMM"},
+	CurrencyCodeForNameCase{"Turkmenistani manat", 2009, "TMT"},
+	CurrencyCodeForNameCase{"Turkish liras", 2004, "TRL"},
+	CurrencyCodeForNameCase{"Turkish liras", 2005, "TRY"},
+	CurrencyCodeForNameCase{"Venezuelan bolivars", 2007, "VEB"},
+	CurrencyCodeForNameCase{"Venezuelan bolivars", 2008, "VEF"},
+	CurrencyCodeForNameCase{"Venezuelan bolivars", 2017, "VEF"},
+	CurrencyCodeForNameCase{"Venezuelan bolivars", 2018, "VES"},
+	CurrencyCodeForNameCase{"Zambian kwacha", 2012, "ZMK"},
+	CurrencyCodeForNameCase{"Zambian kwacha", 2013, "ZMW"},
+	// currencies which were not redenominated
+	CurrencyCodeForNameCase{"euros", 2001, "EUR"},
+	// unknown years use the current code
+	CurrencyCodeForNameCase{"Venezuelan bolivars", 0, "VES"},
+}
+
+func TestCurrencyCodeForName(t *testing.T) {
+	for testIndex, c := range currencyCodeForNameCases {
+		code, exists := currencyCodeForName(c.name, c.year)
+		if !exists || code != c.expectedCode {
+			t.Error("currencyCodeForName code, testIndex: ", testIndex, code)
+		}
+	}
+	_, exists := currencyCodeForName("imaginary crowns", 2017)
+	if exists {
+		t.Error("currencyCodeForName unknown currency")
+	}
+}
+
+func TestRedenominatedCurrencyCodes(t *testing.T) {
+	for code, codes := range redenominatedCurrencyCodes {
+		if codes[len(codes)-1].code != code {
+			t.Error("redenominated currency does not end with its current code", code)
+		}
+		for _, dated := range codes {
+			if !isCurrencyCode(dated.code) {
+				t.Error("redenominated currency code is not a currency code", dated.code)
+			}
+		}
+	}
+}
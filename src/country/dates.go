@@ -0,0 +1,216 @@
+package country
+
+import (
+	"errors"
+	"orderedmap"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var StringToDateErr = errors.New("String could not be converted to date")
+
+// eg FY2015/16, FY16/17, FY2016
+var fiscalYearRe = regexp.MustCompile(`^FY\s?([0-9]{2}|[0-9]{4})(?:\s*/\s*([0-9]{2}|[0-9]{4}))?$`)
+
+// eg 2016/17 which is also a fiscal year
+var slashYearRe = regexp.MustCompile(`^([0-9]{4})\s*/\s*([0-9]{2}|[0-9]{4})$`)
+
+// eg 2010-11, 2005-2010, 2005 to 2010
+var yearRangeRe = regexp.MustCompile(`^([0-9]{4})\s*(?:-|–|to)\s*([0-9]{2}|[0-9]{4})$`)
+
+var estimateSuffixRe = regexp.MustCompile(`\s*(est\.?|es|estimate)$`)
+
+// eg 2011 census, which is not an estimate
+var censusSuffixRe = regexp.MustCompile(`\s+census$`)
+
+var dayLayouts = []string{
+	"2 January 2006",
+	"January 2, 2006",
+	"January 2 2006",
+	"2 Jan 2006",
+	"Jan 2, 2006",
+}
+
+var monthLayouts = []string{
+	"January 2006",
+	"Jan 2006",
+}
+
+// factbookDate is a date as written in the factbook, which may be a day,
+// a month, a year, a fiscal year or a range of years.
+// start is the first day and end is the last day covered by the date.
+// Fiscal years only name the years they cover, eg FY2015/16, since the days
+// depend on the fiscal year of the country, so start and end are left unset
+// and startYear and endYear are used instead. A fiscal year naming one year,
+// eg FY2016, is the fiscal year ending in that year.
+type factbookDate struct {
+	start       time.Time
+	end         time.Time
+	startYear   int
+	endYear     int
+	granularity string
+	isEstimate  bool
+}
+
+// Returns the first and last year named by the date
+func (d factbookDate) years() (int, int) {
+	if d.granularity == "fiscal_year" {
+		return d.startYear, d.endYear
+	}
+	return d.start.Year(), d.end.Year()
+}
+
+// Returns the date in the format used by the "date" key, which is the year
+// for year-like dates and the start day for months and days.
+func (d factbookDate) String() string {
+	if d.granularity == "day" || d.granularity == "month" {
+		return d.start.Format("2006-01-02")
+	}
+	startYear, _ := d.years()
+	return strconv.Itoa(startYear)
+}
+
+func (d factbookDate) toMap() *orderedmap.OrderedMap {
+	o := orderedmap.New()
+	if d.granularity == "fiscal_year" {
+		o.Set("start_year", d.startYear)
+		o.Set("end_year", d.endYear)
+	} else {
+		o.Set("start", d.start.Format("2006-01-02"))
+		o.Set("end", d.end.Format("2006-01-02"))
+	}
+	o.Set("granularity", d.granularity)
+	o.Set("is_estimate", d.isEstimate)
+	return o
+}
+
+// Sets both the date and the date_detail keys
+func setDate(o *orderedmap.OrderedMap, d factbookDate) {
+	o.Set("date", d.String())
+	o.Set("date_detail", d.toMap())
+}
+
+func yearsToDate(startYear, endYear int, granularity string) factbookDate {
+	return factbookDate{
+		start:       time.Date(startYear, time.January, 1, 0, 0, 0, 0, time.UTC),
+		end:         time.Date(endYear, time.December, 31, 0, 0, 0, 0, time.UTC),
+		granularity: granularity,
+	}
+}
+
+// Expands the second year of a range, eg 2016/17 ends in 2017 and
+// 1999/00 ends in 2000
+func endYearAfter(startYear int, endStr string) int {
+	endYear, _ := strconv.Atoi(endStr)
+	if len(endStr) == 4 {
+		return endYear
+	}
+	century := startYear - startYear%100
+	endYear = century + endYear
+	if endYear < startYear {
+		endYear = endYear + 100
+	}
+	return endYear
+}
+
+// Converts a date such as "2016", "July 2017 est.", "December 31, 2016",
+// "FY2015/16 est." or "2010-11" into a factbookDate
+func stringToDate(s string) (factbookDate, error) {
+	d := factbookDate{}
+	s = strings.TrimSpace(atLeastOneSpaceRe.ReplaceAllString(s, " "))
+	if estimateSuffixRe.MatchString(s) {
+		d.isEstimate = true
+		s = estimateSuffixRe.ReplaceAllString(s, "")
+	}
+	s = censusSuffixRe.ReplaceAllString(s, "")
+	if len(s) == 0 {
+		return d, StringToDateErr
+	}
+	isEstimate := d.isEstimate
+	// fiscal year, before years since FY04 is also 4 characters
+	m := fiscalYearRe.FindStringSubmatch(s)
+	if m == nil {
+		m = slashYearRe.FindStringSubmatch(s)
+	}
+	if m != nil {
+		startYear, _ := strconv.Atoi(m[1])
+		// two digit years, eg FY99/00
+		if len(m[1]) == 2 && startYear < 50 {
+			startYear = startYear + 2000
+		} else if len(m[1]) == 2 {
+			startYear = startYear + 1900
+		}
+		endYear := startYear
+		if m[2] != "" {
+			endYear = endYearAfter(startYear, m[2])
+		}
+		d = factbookDate{
+			startYear:   startYear,
+			endYear:     endYear,
+			granularity: "fiscal_year",
+			isEstimate:  isEstimate,
+		}
+		return d, nil
+	}
+	// year
+	if len(s) == 4 {
+		t, err := time.Parse("2006", s)
+		if err != nil {
+			return d, StringToDateErr
+		}
+		d = yearsToDate(t.Year(), t.Year(), "year")
+		d.isEstimate = isEstimate
+		return d, nil
+	}
+	// range of years
+	m = yearRangeRe.FindStringSubmatch(s)
+	if m != nil {
+		startYear, _ := strconv.Atoi(m[1])
+		endYear := endYearAfter(startYear, m[2])
+		if endYear < startYear {
+			return d, StringToDateErr
+		}
+		d = yearsToDate(startYear, endYear, "year")
+		d.isEstimate = isEstimate
+		return d, nil
+	}
+	// day
+	for _, layout := range dayLayouts {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+		d.start = t
+		d.end = t
+		d.granularity = "day"
+		return d, nil
+	}
+	// month
+	for _, layout := range monthLayouts {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+		d.start = t
+		d.end = t.AddDate(0, 1, -1)
+		d.granularity = "month"
+		return d, nil
+	}
+	return d, StringToDateErr
+}
+
+// Removes the first date in parenthesis from s and returns it
+func stringWithoutDateDetail(s string) (string, factbookDate, bool) {
+	_, ps := removeParenthesis(s)
+	for _, p := range ps {
+		d, err := stringToDate(p)
+		if err != nil {
+			continue
+		}
+		sNoDate := strings.Replace(s, "("+p+")", "", -1)
+		return strings.TrimSpace(sNoDate), d, true
+	}
+	return strings.TrimSpace(s), factbookDate{}, false
+}
@@ -0,0 +1,121 @@
+package country
+
+import (
+	"errors"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type StringToDateCase struct {
+	s                   string
+	expectedStart       string
+	expectedEnd         string
+	expectedGranularity string
+	expectedIsEstimate  bool
+}
+
+// Reads the date formats found across the archive from testdata/dates.txt
+func stringToDateCases() ([]StringToDateCase, error) {
+	cases := []StringToDateCase{}
+	b, err := ioutil.ReadFile("testdata/dates.txt")
+	if err != nil {
+		return cases, err
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		if len(strings.TrimSpace(line)) == 0 || startsWith(line, "#") {
+			continue
+		}
+		bits := strings.Split(line, "|")
+		if len(bits) != 5 {
+			return cases, errors.New("Invalid line in testdata/dates.txt: " + line)
+		}
+		cases = append(cases, StringToDateCase{
+			s:                   strings.TrimSpace(bits[0]),
+			expectedStart:       strings.TrimSpace(bits[1]),
+			expectedEnd:         strings.TrimSpace(bits[2]),
+			expectedGranularity: strings.TrimSpace(bits[3]),
+			expectedIsEstimate:  strings.TrimSpace(bits[4]) == "true",
+		})
+	}
+	return cases, nil
+}
+
+func TestStringToDate(t *testing.T) {
+	cases, err := stringToDateCases()
+	if err != nil {
+		t.Error("stringToDate fixtures error", err)
+		return
+	}
+	for _, c := range cases {
+		d, err := stringToDate(c.s)
+		if c.expectedGranularity == "error" {
+			if err != StringToDateErr {
+				t.Error("stringToDate should fail:", c.s)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error("stringToDate error:", c.s, err)
+			continue
+		}
+		start := d.start.Format("2006-01-02")
+		end := d.end.Format("2006-01-02")
+		if d.granularity == "fiscal_year" {
+			start = strconv.Itoa(d.startYear)
+			end = strconv.Itoa(d.endYear)
+		}
+		if start != c.expectedStart {
+			t.Error("stringToDate start:", c.s, start)
+		}
+		if end != c.expectedEnd {
+			t.Error("stringToDate end:", c.s, end)
+		}
+		if d.granularity != c.expectedGranularity {
+			t.Error("stringToDate granularity:", c.s, d.granularity)
+		}
+		if d.isEstimate != c.expectedIsEstimate {
+			t.Error("stringToDate isEstimate:", c.s, d.isEstimate)
+		}
+	}
+}
+
+func TestStringWithoutDateDetail(t *testing.T) {
+	s, d, hasDate := stringWithoutDateDetail("$1.2 billion (FY2015/16 est.)")
+	if !hasDate {
+		t.Error("stringWithoutDateDetail has no date")
+		return
+	}
+	if s != "$1.2 billion" {
+		t.Error("stringWithoutDateDetail value", s)
+	}
+	// the date string keeps the first year for compatibility
+	if d.String() != "2015" {
+		t.Error("stringWithoutDateDetail date string", d.String())
+	}
+	// parenthesis which are not dates are kept
+	s, _, hasDate = stringWithoutDateDetail("1,234 km (landlocked)")
+	if hasDate || s != "1,234 km (landlocked)" {
+		t.Error("stringWithoutDateDetail without date", s)
+	}
+}
+
+func TestFiscalYearDateDetail(t *testing.T) {
+	// fiscal years have no days until the fiscal year of the country is known
+	_, d, _ := stringWithoutDateDetail("$370.7 billion (FY04 est.)")
+	o := d.toMap()
+	_, hasStart := o.Get("start")
+	startYear, _ := o.Get("start_year")
+	endYear, _ := o.Get("end_year")
+	if hasStart || startYear != 2004 || endYear != 2004 {
+		t.Error("fiscal year date detail", o.Keys(), startYear, endYear)
+	}
+	if d.String() != "2004" {
+		t.Error("fiscal year date string", d.String())
+	}
+	back, err := dateDetailToDate(o)
+	if err != nil || back.startYear != 2004 || back.granularity != "fiscal_year" {
+		t.Error("fiscal year date detail round trip", back, err)
+	}
+}
@@ -0,0 +1,141 @@
+package country
+
+import (
+	"orderedmap"
+)
+
+// derivedInput is a parsed value used to calculate a derived indicator
+type derivedInput struct {
+	field string
+	value float64
+	units string
+	date  string
+}
+
+func (in derivedInput) toMap() *orderedmap.OrderedMap {
+	o := orderedmap.New()
+	o.Set("field", in.field)
+	o.Set("value", in.value)
+	if len(in.units) > 0 {
+		o.Set("units", in.units)
+	}
+	if len(in.date) > 0 {
+		o.Set("date", in.date)
+	}
+	return o
+}
+
+// Returns the most recent of a list of annual values, or the first value if
+// the values have no dates
+func latestAnnualValue(v interface{}, keys ...string) (interface{}, bool) {
+	list, exists := valueAtPath(v, keys...)
+	if !exists {
+		return nil, false
+	}
+	values := []interface{}{}
+	switch l := list.(type) {
+	case []*orderedmap.OrderedMap:
+		for _, item := range l {
+			values = append(values, item)
+		}
+	case []interface{}:
+		values = l
+	}
+	var latest interface{}
+	latestDate := ""
+	for _, item := range values {
+		date := stringAtPath(item, "date")
+		if latest == nil || date > latestDate {
+			latest = item
+			latestDate = date
+		}
+	}
+	return latest, latest != nil
+}
+
+func populationInput(data interface{}) (derivedInput, bool) {
+	population, exists := floatAtPath(data, "people", "population", "total")
+	if !exists || population <= 0 {
+		return derivedInput{}, false
+	}
+	date := stringAtPath(data, "people", "population", "date")
+	return derivedInput{"people.population.total", population, "people", date}, true
+}
+
+func gdpInput(data interface{}) (derivedInput, bool) {
+	gdp, exists := latestAnnualValue(data, "economy", "gdp", "purchasing_power_parity", "annual_values")
+	if !exists {
+		return derivedInput{}, false
+	}
+	value, isFloat := floatAtPath(gdp, "value")
+	if !isFloat {
+		return derivedInput{}, false
+	}
+	field := "economy.gdp.purchasing_power_parity"
+	return derivedInput{field, value, stringAtPath(gdp, "units"), stringAtPath(gdp, "date")}, true
+}
+
+// Areas are converted to sq km using the canonical value in sq m
+func areaInput(data interface{}) (derivedInput, bool) {
+	area, exists := floatAtPath(data, "geography", "area", "total", "value_si")
+	if !exists || area <= 0 {
+		return derivedInput{}, false
+	}
+	return derivedInput{"geography.area.total", area / 1e6, "sq km", ""}, true
+}
+
+func electricityInput(data interface{}) (derivedInput, bool) {
+	consumption, exists := floatAtPath(data, "energy", "electricity", "consumption", "kWh")
+	if !exists {
+		return derivedInput{}, false
+	}
+	date := stringAtPath(data, "energy", "electricity", "consumption", "date")
+	return derivedInput{"energy.electricity.consumption", consumption, "kWh", date}, true
+}
+
+func derivedValue(value float64, units, formula string, inputs ...derivedInput) *orderedmap.OrderedMap {
+	o := orderedmap.New()
+	o.Set("value", value)
+	o.Set("units", units)
+	o.Set("formula", formula)
+	inputMaps := []*orderedmap.OrderedMap{}
+	for _, in := range inputs {
+		inputMaps = append(inputMaps, in.toMap())
+	}
+	o.Set("inputs", inputMaps)
+	return o
+}
+
+// Calculates indicators which the factbook does not report from the parsed
+// data, eg population density. data is the data section of a parsed page.
+// Each indicator has the formula and the inputs with their dates, since the
+// inputs are often from different years.
+func DerivedIndicators(data interface{}) (*orderedmap.OrderedMap, error) {
+	derived := orderedmap.New()
+	population, hasPopulation := populationInput(data)
+	if !hasPopulation {
+		return derived, NoValueErr
+	}
+	gdp, hasGdp := gdpInput(data)
+	if hasGdp {
+		v := derivedValue(gdp.value/population.value, gdp.units,
+			"economy.gdp.purchasing_power_parity / people.population.total", gdp, population)
+		derived.Set("gdp_per_capita_purchasing_power_parity", v)
+	}
+	area, hasArea := areaInput(data)
+	if hasArea {
+		v := derivedValue(population.value/area.value, "people per sq km",
+			"people.population.total / geography.area.total", population, area)
+		derived.Set("population_density", v)
+	}
+	electricity, hasElectricity := electricityInput(data)
+	if hasElectricity {
+		v := derivedValue(electricity.value/population.value, "kWh per person",
+			"energy.electricity.consumption / people.population.total", electricity, population)
+		derived.Set("electricity_consumption_per_capita", v)
+	}
+	if len(derived.Keys()) == 0 {
+		return derived, NoValueErr
+	}
+	return derived, nil
+}
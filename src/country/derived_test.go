@@ -0,0 +1,94 @@
+package country
+
+import (
+	"encoding/json"
+	"math"
+	"orderedmap"
+	"testing"
+)
+
+// Parsed data as json so the test covers both Page.parse and weekly files
+const derivedTestJson = `{
+	"geography": {
+		"area": {
+			"total": {"value": 1000, "units": "sq km", "value_si": 1000000000}
+		}
+	},
+	"people": {
+		"population": {"total": 50000, "date": "2017"}
+	},
+	"economy": {
+		"gdp": {
+			"purchasing_power_parity": {
+				"annual_values": [
+					{"value": 900000000, "units": "USD", "date": "2015"},
+					{"value": 1000000000, "units": "USD", "date": "2016"}
+				]
+			}
+		}
+	},
+	"energy": {
+		"electricity": {
+			"consumption": {"kWh": 200000000, "date": "2014"}
+		}
+	}
+}`
+
+func TestDerivedIndicators(t *testing.T) {
+	data := orderedmap.New()
+	err := json.Unmarshal([]byte(derivedTestJson), data)
+	if err != nil {
+		t.Error("DerivedIndicators json error", err)
+		return
+	}
+	derived, err := DerivedIndicators(data)
+	if err != nil {
+		t.Error("DerivedIndicators error", err)
+		return
+	}
+	expected := map[string]float64{
+		// uses the most recent gdp
+		"gdp_per_capita_purchasing_power_parity": 20000,
+		"population_density":                     50,
+		"electricity_consumption_per_capita":     4000,
+	}
+	expectedUnits := map[string]string{
+		"gdp_per_capita_purchasing_power_parity": "USD",
+		"population_density":                     "people per sq km",
+		"electricity_consumption_per_capita":     "kWh per person",
+	}
+	for key, expectedValue := range expected {
+		v, exists := derived.Get(key)
+		if !exists {
+			t.Error("DerivedIndicators missing", key)
+			continue
+		}
+		value, _ := v.(*orderedmap.OrderedMap).Get("value")
+		if math.Abs(value.(float64)-expectedValue) > 1e-9 {
+			t.Error("DerivedIndicators value", key, value)
+		}
+		units, _ := v.(*orderedmap.OrderedMap).Get("units")
+		if units != expectedUnits[key] {
+			t.Error("DerivedIndicators units", key, units)
+		}
+		inputs, _ := v.(*orderedmap.OrderedMap).Get("inputs")
+		if len(inputs.([]*orderedmap.OrderedMap)) != 2 {
+			t.Error("DerivedIndicators inputs", key, inputs)
+		}
+	}
+	unknown := UnknownUnits(derived)
+	if len(unknown) > 0 {
+		t.Error("DerivedIndicators unknown units", unknown)
+	}
+	gdp, _ := derived.Get("gdp_per_capita_purchasing_power_parity")
+	inputs, _ := gdp.(*orderedmap.OrderedMap).Get("inputs")
+	date, _ := inputs.([]*orderedmap.OrderedMap)[0].Get("date")
+	if date != "2016" {
+		t.Error("DerivedIndicators gdp date", date)
+	}
+	// no population
+	_, err = DerivedIndicators(orderedmap.New())
+	if err != NoValueErr {
+		t.Error("DerivedIndicators without population", err)
+	}
+}
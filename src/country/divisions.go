@@ -0,0 +1,97 @@
+package country
+
+import (
+	"orderedmap"
+	"strings"
+)
+
+// Normalised division types in the order they are checked, so more specific
+// types come before the general types they contain, eg autonomous region
+// before region. Each type has the words which identify it.
+var divisionTypes = []struct {
+	normalised string
+	words      []string
+}{
+	{"special administrative region", []string{"special administrative region"}},
+	{"autonomous republic", []string{"autonomous republic"}},
+	{"autonomous region", []string{"autonomous region", "autonomous communit", "autonomous province", "autonomous district", "autonomous cit"}},
+	{"capital", []string{"capital"}},
+	{"municipality", []string{"municipalit"}},
+	{"governorate", []string{"governorate", "governate"}},
+	{"oblast", []string{"oblast"}},
+	{"krai", []string{"krai", "kray"}},
+	{"voivodeship", []string{"voivodship", "voivodeship"}},
+	{"raion", []string{"raion", "rayon"}},
+	{"emirate", []string{"emirate"}},
+	{"prefecture", []string{"prefecture"}},
+	{"province", []string{"province"}},
+	{"region", []string{"region"}},
+	{"state", []string{"state"}},
+	{"territory", []string{"territor"}},
+	{"department", []string{"department"}},
+	{"district", []string{"district"}},
+	{"county", []string{"county", "counties"}},
+	{"parish", []string{"parish"}},
+	{"canton", []string{"canton"}},
+	{"commune", []string{"commune"}},
+	{"island", []string{"island"}},
+	{"city", []string{"city", "cities"}},
+	{"republic", []string{"republic"}},
+	{"division", []string{"division"}},
+}
+
+// Returns the normalised type for a division type as written in the
+// factbook, eg "cities with provincial status" is a city.
+// Types which are not known are returned unchanged.
+func NormaliseDivisionType(s string) string {
+	lower := strings.ToLower(strings.TrimSpace(s))
+	for _, t := range divisionTypes {
+		for _, word := range t.words {
+			if strings.Index(lower, word) > -1 {
+				return t.normalised
+			}
+		}
+	}
+	return lower
+}
+
+func setNormalisedDivisionTypes(divisions []*orderedmap.OrderedMap) {
+	for _, d := range divisions {
+		t, _ := d.Get("type")
+		tStr, _ := t.(string)
+		d.Set("normalised_type", NormaliseDivisionType(tStr))
+	}
+}
+
+// Returns the number of divisions of each normalised type in the order they
+// first appear, with the total
+func countDivisions(divisions []*orderedmap.OrderedMap) *orderedmap.OrderedMap {
+	counts := orderedmap.New()
+	byType := orderedmap.New()
+	for _, d := range divisions {
+		t, _ := d.Get("normalised_type")
+		tStr, _ := t.(string)
+		if len(tStr) == 0 {
+			tStr = "unknown"
+		}
+		count, _ := byType.Get(tStr)
+		countInt, _ := count.(int)
+		byType.Set(tStr, countInt+1)
+	}
+	counts.Set("total", len(divisions))
+	counts.Set("by_type", byType)
+	return counts
+}
+
+func (p *Page) administrativeDivisionCounts() (interface{}, error) {
+	value, err := textForSelector(p.dom, Selector{"2051", "government-administrative-divisions"})
+	if err != nil {
+		return value, err
+	}
+	value = strings.Replace(value, "\t", " ", -1)
+	divisions, err := administrativeDivisions(value)
+	if err != nil {
+		return divisions, err
+	}
+	return countDivisions(divisions.([]*orderedmap.OrderedMap)), nil
+}
@@ -0,0 +1,94 @@
+package country
+
+import (
+	"orderedmap"
+	"testing"
+)
+
+type AdministrativeDivisionsCase struct {
+	s              string
+	expectedTypes  []string
+	expectedCounts map[string]int
+}
+
+var administrativeDivisionsCases = []AdministrativeDivisionsCase{
+	// primary and secondary types, secondary marked by *
+	AdministrativeDivisionsCase{
+		s:             "3 provinces and 1 capital city*; Bengo, Benguela, Bie, Luanda*",
+		expectedTypes: []string{"province", "province", "province", "capital"},
+		expectedCounts: map[string]int{
+			"province": 3,
+			"capital":  1,
+		},
+	},
+	AdministrativeDivisionsCase{
+		s:             "2 governorates; Al Asimah, Al Janubiyah",
+		expectedTypes: []string{"governorate", "governorate"},
+		expectedCounts: map[string]int{
+			"governorate": 2,
+		},
+	},
+	// key based, see Moldova
+	AdministrativeDivisionsCase{
+		s:             "raions: Anenii Noi, Basarabeasca\nmunicipalities: Balti, Chisinau",
+		expectedTypes: []string{"raion", "raion", "municipality", "municipality"},
+		expectedCounts: map[string]int{
+			"raion":        2,
+			"municipality": 2,
+		},
+	},
+}
+
+func TestAdministrativeDivisions(t *testing.T) {
+	for testIndex, c := range administrativeDivisionsCases {
+		v, err := administrativeDivisions(c.s)
+		if err != nil {
+			t.Error("administrativeDivisions error, testIndex: ", testIndex, err)
+			continue
+		}
+		divisions := v.([]*orderedmap.OrderedMap)
+		if len(divisions) != len(c.expectedTypes) {
+			t.Error("administrativeDivisions length, testIndex: ", testIndex, len(divisions))
+			continue
+		}
+		for i, d := range divisions {
+			normalised, _ := d.Get("normalised_type")
+			if normalised != c.expectedTypes[i] {
+				t.Error("administrativeDivisions type, testIndex: ", testIndex, i, normalised)
+			}
+		}
+		counts := countDivisions(divisions)
+		total, _ := counts.Get("total")
+		if total != len(c.expectedTypes) {
+			t.Error("administrativeDivisions total, testIndex: ", testIndex, total)
+		}
+		byTypeInterface, _ := counts.Get("by_type")
+		byType := byTypeInterface.(*orderedmap.OrderedMap)
+		if len(byType.Keys()) != len(c.expectedCounts) {
+			t.Error("administrativeDivisions types, testIndex: ", testIndex, byType.Keys())
+		}
+		for key, expected := range c.expectedCounts {
+			count, _ := byType.Get(key)
+			if count != expected {
+				t.Error("administrativeDivisions count, testIndex: ", testIndex, key, count)
+			}
+		}
+	}
+}
+
+func TestNormaliseDivisionType(t *testing.T) {
+	cases := map[string]string{
+		"cities with provincial status": "city",
+		"autonomous regions":            "autonomous region",
+		"oblasts":                       "oblast",
+		"counties":                      "county",
+		"governate":                     "governorate",
+		"entities":                      "entities",
+	}
+	for s, expected := range cases {
+		normalised := NormaliseDivisionType(s)
+		if normalised != expected {
+			t.Error("NormaliseDivisionType", s, normalised)
+		}
+	}
+}
@@ -19,6 +19,17 @@ var FilenameBlacklist = []string{
 	"fq.html", // Baker Island
 }
 
+// Pages which are not countries but combine data for many countries
+var AggregateFilenames = []string{
+	"xx.html", // World
+	"ee.html", // European Union
+	"xq.html", // Arctic Ocean
+	"zh.html", // Atlantic Ocean
+	"xo.html", // Indian Ocean
+	"zn.html", // Pacific Ocean
+	"oo.html", // Southern Ocean
+}
+
 func countryListFromDom(doc *goquery.Document) ([]string, error) {
 	l := []string{}
 	// get the select element
@@ -47,6 +58,15 @@ func countryListFromDom(doc *goquery.Document) ([]string, error) {
 	return l, nil
 }
 
+func IsAggregate(f string) bool {
+	for _, filename := range AggregateFilenames {
+		if f == filename {
+			return true
+		}
+	}
+	return false
+}
+
 func fileIsBlacklisted(f string) bool {
 	for _, filename := range FilenameBlacklist {
 		if f == filename {
@@ -140,6 +160,37 @@ func countryNameFromDom(doc *goquery.Document) (string, error) {
 	return name, nil
 }
 
+// Returns the region name, code and source from the page. Recent pages link
+// to the region page, eg wfbExt/region_cam.html. Older pages style each
+// category with the region code, eg class='category aus_light', and the name
+// is from the regions table.
+func regionFromDom(doc *goquery.Document) (string, string, string, error) {
+	link := doc.Find("a[href*='wfbExt/region_']").First()
+	href, exists := link.Attr("href")
+	if exists {
+		name := strings.TrimSpace(link.Text())
+		code := href[strings.LastIndex(href, "region_")+len("region_") : len(href)]
+		code = strings.TrimSuffix(code, ".html")
+		r, isRegion := regionForCode[code]
+		if isRegion && len(name) == 0 {
+			name = r.name
+		}
+		if isRegion {
+			return name, code, "region_link", nil
+		}
+	}
+	class, exists := doc.Find(".category[class*='_light']").First().Attr("class")
+	if exists {
+		for _, c := range strings.Fields(class) {
+			r, isRegion := regionForCode[strings.TrimSuffix(c, "_light")]
+			if strings.HasSuffix(c, "_light") && isRegion {
+				return r.name, r.code, "category_style", nil
+			}
+		}
+	}
+	return "", "", "", NoRegionError
+}
+
 func nationalAnthemMp3FromDom(doc *goquery.Document) (string, error) {
 	root := "https://www.cia.gov/library/publications/the-world-factbook/"
 	audio := doc.Find("audio")
@@ -0,0 +1,249 @@
+package country
+
+import (
+	"math"
+	"orderedmap"
+	"strings"
+	"time"
+)
+
+// The largest difference in percentage points between the balance calculated
+// from revenues and expenditures and the reported surplus or deficit before
+// they are considered inconsistent
+const fiscalBalanceTolerance = 1.0
+
+// Currency symbols used before budget values, longest first so US$ is not
+// read as $
+var budgetCurrencySymbols = []struct {
+	symbol string
+	code   string
+}{
+	{"US$", "USD"},
+	{"$", "USD"},
+	{"€", "EUR"},
+	{"£", "GBP"},
+	{"¥", "JPY"},
+}
+
+// Returns the currency code for a budget value and the value without the
+// currency symbol. Values without a symbol or code are USD, which is the
+// currency used for budgets on almost every page.
+func budgetValueCurrency(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	for _, c := range budgetCurrencySymbols {
+		if startsWith(s, c.symbol) {
+			return strings.TrimSpace(s[len(c.symbol):len(s)]), c.code
+		}
+	}
+	for _, word := range strings.Fields(s) {
+		if isCurrencyCode(word) {
+			return strings.Replace(s, word, "", 1), word
+		}
+	}
+	return s, "USD"
+}
+
+// Returns true if s is the code of a currency in the exchange rates field
+func isCurrencyCode(s string) bool {
+	if !currencyCodeRe.MatchString(s) {
+		return false
+	}
+	for _, code := range currencyCodes {
+		if code == s {
+			return true
+		}
+	}
+	for _, codes := range redenominatedCurrencyCodes {
+		for _, dated := range codes {
+			if dated.code == s {
+				return true
+			}
+		}
+	}
+	return s == "USD"
+}
+
+// Returns the days covered by a budget, using the start and end of the
+// fiscal year. Single years in the budget are the year the fiscal year ends,
+// eg 2017 for a fiscal year of 1 October - 30 September is 1 October 2016 to
+// 30 September 2017.
+func fiscalPeriod(d factbookDate, fiscalYearStart, fiscalYearEnd string) (time.Time, time.Time, error) {
+	start, err := time.Parse("2 January", strings.TrimSpace(fiscalYearStart))
+	if err != nil {
+		return start, start, err
+	}
+	end, err := time.Parse("2 January", strings.TrimSpace(fiscalYearEnd))
+	if err != nil {
+		return start, end, err
+	}
+	startYear, endYear := d.years()
+	// single years of fiscal years which cross the new year. Dates which
+	// name both years, eg FY2015/16 or 2010-11, already start in the first.
+	if end.Before(start) && startYear == endYear {
+		startYear = startYear - 1
+	}
+	periodStart := time.Date(startYear, start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(endYear, end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return periodStart, periodEnd, nil
+}
+
+// Returns the units per USD for a currency code from the exchange rates,
+// using the rate for the date if there is one, otherwise the latest rate.
+func exchangeRateForCurrency(exchangeRates *orderedmap.OrderedMap, code, date string) (float64, bool) {
+	rates := []*orderedmap.OrderedMap{exchangeRates}
+	byCurrency, exists := exchangeRates.Get("by_currency")
+	if exists {
+		rates, _ = byCurrency.([]*orderedmap.OrderedMap)
+	}
+	for _, rate := range rates {
+		rateCode, _ := rate.Get("currency_code")
+		if rateCode != code {
+			continue
+		}
+		annualValues, _ := rate.Get("annual_values")
+		values, _ := annualValues.([]*orderedmap.OrderedMap)
+		for _, v := range values {
+			valueDate, _ := v.Get("date")
+			if valueDate == date {
+				return floatAtPath(v, "value")
+			}
+		}
+		if len(values) > 0 {
+			return floatAtPath(values[0], "value")
+		}
+	}
+	return 0, false
+}
+
+// Compares revenues minus expenditures with the reported surplus or deficit.
+// Both are converted to percent of GDP using the GDP at the official
+// exchange rate. Budgets in other currencies are converted to USD using the
+// exchange rate for the currency.
+func fiscalChecks(budget, surplus, gdp, exchangeRates *orderedmap.OrderedMap) (*orderedmap.OrderedMap, error) {
+	checks := orderedmap.New()
+	revenues, hasRevenues := floatAtPath(budget, "revenues", "value")
+	expenditures, hasExpenditures := floatAtPath(budget, "expenditures", "value")
+	if !hasRevenues || !hasExpenditures {
+		return checks, NoValueErr
+	}
+	revenueUnits, _ := budget.Get("revenues")
+	units, _ := revenueUnits.(*orderedmap.OrderedMap).Get("units")
+	balance := revenues - expenditures
+	checks.Set("balance", balance)
+	checks.Set("units", units)
+	gdpValue, hasGdp := floatAtPath(gdp, "USD")
+	reported, hasReported := floatAtPath(surplus, "percent_of_gdp")
+	if !hasGdp || gdpValue == 0 || !hasReported {
+		return checks, nil
+	}
+	balanceUsd := balance
+	if units != "USD" {
+		code, _ := units.(string)
+		date, _ := budget.Get("date")
+		dateStr, _ := date.(string)
+		rate, hasRate := exchangeRateForCurrency(exchangeRates, code, dateStr)
+		if !hasRate || rate == 0 {
+			return checks, nil
+		}
+		balanceUsd = balance / rate
+		checks.Set("exchange_rate", rate)
+	}
+	balancePercent := balanceUsd / gdpValue * 100
+	difference := balancePercent - reported
+	checks.Set("balance_percent_of_gdp", balancePercent)
+	checks.Set("reported_percent_of_gdp", reported)
+	checks.Set("difference", difference)
+	checks.Set("consistent", math.Abs(difference) <= fiscalBalanceTolerance)
+	return checks, nil
+}
+
+// The economy fields covered by the fiscal year
+var fiscalKeys = []string{
+	"budget",
+	"taxes_and_other_revenues",
+	"budget_surplus_or_deficit",
+	"public_debt",
+}
+
+// The fiscal block links the budget, taxes, surplus or deficit and public
+// debt under economy with the fiscal year they cover, and checks they agree.
+// The fields are listed by key rather than copied.
+func fiscal(economy *orderedmap.OrderedMap) (*orderedmap.OrderedMap, error) {
+	o := orderedmap.New()
+	fields := []string{}
+	for _, key := range fiscalKeys {
+		if _, exists := economy.Get(key); exists {
+			fields = append(fields, key)
+		}
+	}
+	if len(fields) == 0 {
+		return o, NoValueErr
+	}
+	o.Set("fields", fields)
+	fy := mapAtPath(economy, "fiscal_year")
+	b := mapAtPath(economy, "budget")
+	// the days covered by the budget
+	fyStart, hasFyStart := fy.Get("start")
+	fyEnd, hasFyEnd := fy.Get("end")
+	dateDetail, hasDate := b.Get("date_detail")
+	if hasFyStart && hasFyEnd && hasDate {
+		fyStartStr, _ := fyStart.(string)
+		fyEndStr, _ := fyEnd.(string)
+		dateDetailMap, _ := dateDetail.(*orderedmap.OrderedMap)
+		if dateDetailMap != nil {
+			d, err := dateDetailToDate(dateDetailMap)
+			if err == nil {
+				start, end, err := fiscalPeriod(d, fyStartStr, fyEndStr)
+				if err == nil {
+					period := orderedmap.New()
+					period.Set("start", start.Format("2006-01-02"))
+					period.Set("end", end.Format("2006-01-02"))
+					o.Set("period", period)
+				}
+			}
+		}
+	}
+	checks, err := fiscalChecks(b, mapAtPath(economy, "budget_surplus_or_deficit"), mapAtPath(economy, "gdp", "official_exchange_rate"), mapAtPath(economy, "exchange_rates"))
+	if err == nil {
+		o.Set("checks", checks)
+	}
+	return o, nil
+}
+
+// Converts the date_detail map back into a factbookDate
+func dateDetailToDate(o *orderedmap.OrderedMap) (factbookDate, error) {
+	d := factbookDate{}
+	granularity, _ := o.Get("granularity")
+	d.granularity, _ = granularity.(string)
+	if d.granularity == "fiscal_year" {
+		startYear, hasStart := o.Get("start_year")
+		endYear, hasEnd := o.Get("end_year")
+		d.startYear, _ = startYear.(int)
+		d.endYear, _ = endYear.(int)
+		if !hasStart || !hasEnd || d.startYear == 0 || d.endYear == 0 {
+			return d, StringToDateErr
+		}
+		return d, nil
+	}
+	start, _ := o.Get("start")
+	end, _ := o.Get("end")
+	startStr, isString := start.(string)
+	if !isString {
+		return d, StringToDateErr
+	}
+	endStr, isString := end.(string)
+	if !isString {
+		return d, StringToDateErr
+	}
+	startTime, err := time.Parse("2006-01-02", startStr)
+	if err != nil {
+		return d, err
+	}
+	endTime, err := time.Parse("2006-01-02", endStr)
+	if err != nil {
+		return d, err
+	}
+	d.start = startTime
+	d.end = endTime
+	return d, nil
+}
@@ -0,0 +1,209 @@
+package country
+
+import (
+	"math"
+	"orderedmap"
+	"testing"
+)
+
+type BudgetCase struct {
+	s                    string
+	expectedRevenues     float64
+	expectedExpenditures float64
+	expectedUnits        string
+}
+
+var budgetCases = []BudgetCase{
+	BudgetCase{
+		s:                    "revenues: $3.315 trillion\nexpenditures: $3.981 trillion (2017 est.)",
+		expectedRevenues:     3.315e12,
+		expectedExpenditures: 3.981e12,
+		expectedUnits:        "USD",
+	},
+	BudgetCase{
+		s:                    "revenues: €1.2 billion\nexpenditures: €1.5 billion (2016 est.)",
+		expectedRevenues:     1.2e9,
+		expectedExpenditures: 1.5e9,
+		expectedUnits:        "EUR",
+	},
+	// no currency symbol
+	BudgetCase{
+		s:                    "revenues: 45.5 million\nexpenditures: 50 million (2015)",
+		expectedRevenues:     45.5e6,
+		expectedExpenditures: 50e6,
+		expectedUnits:        "USD",
+	},
+}
+
+func TestBudget(t *testing.T) {
+	for testIndex, c := range budgetCases {
+		v, err := budget(c.s)
+		if err != nil {
+			t.Error("budget error, testIndex: ", testIndex, err)
+			continue
+		}
+		o := v.(*orderedmap.OrderedMap)
+		revenues, _ := floatAtPath(o, "revenues", "value")
+		if math.Abs(revenues-c.expectedRevenues) > 1 {
+			t.Error("budget revenues, testIndex: ", testIndex, revenues)
+		}
+		expenditures, _ := floatAtPath(o, "expenditures", "value")
+		if math.Abs(expenditures-c.expectedExpenditures) > 1 {
+			t.Error("budget expenditures, testIndex: ", testIndex, expenditures)
+		}
+		r, _ := o.Get("revenues")
+		units, _ := r.(*orderedmap.OrderedMap).Get("units")
+		if units != c.expectedUnits {
+			t.Error("budget units, testIndex: ", testIndex, units)
+		}
+	}
+}
+
+type FiscalPeriodCase struct {
+	date          string
+	start         string
+	end           string
+	expectedStart string
+	expectedEnd   string
+}
+
+var fiscalPeriodCases = []FiscalPeriodCase{
+	FiscalPeriodCase{"2017 est.", "1 January", "31 December", "2017-01-01", "2017-12-31"},
+	// see United States
+	FiscalPeriodCase{"2017 est.", "1 October", "30 September", "2016-10-01", "2017-09-30"},
+	// see India
+	FiscalPeriodCase{"FY2015/16 est.", "1 April", "31 March", "2015-04-01", "2016-03-31"},
+	// a year range covers one fiscal year from the first year
+	FiscalPeriodCase{"2010-11 est.", "1 July", "30 June", "2010-07-01", "2011-06-30"},
+}
+
+func TestFiscalPeriod(t *testing.T) {
+	for testIndex, c := range fiscalPeriodCases {
+		d, err := stringToDate(c.date)
+		if err != nil {
+			t.Error("fiscalPeriod date error, testIndex: ", testIndex, err)
+			continue
+		}
+		start, end, err := fiscalPeriod(d, c.start, c.end)
+		if err != nil {
+			t.Error("fiscalPeriod error, testIndex: ", testIndex, err)
+			continue
+		}
+		if start.Format("2006-01-02") != c.expectedStart {
+			t.Error("fiscalPeriod start, testIndex: ", testIndex, start)
+		}
+		if end.Format("2006-01-02") != c.expectedEnd {
+			t.Error("fiscalPeriod end, testIndex: ", testIndex, end)
+		}
+	}
+}
+
+func TestFiscalChecks(t *testing.T) {
+	b, _ := budget("revenues: $3.315 trillion\nexpenditures: $3.981 trillion (2017 est.)")
+	surplus, _ := budgetSurplusOrDeficit("-3.4% of GDP (2017 est.)")
+	gdp, _ := gdpOfficialExchangeRate("$19.36 trillion (2017 est.)")
+	checks, err := fiscalChecks(b.(*orderedmap.OrderedMap), surplus.(*orderedmap.OrderedMap), gdp.(*orderedmap.OrderedMap), orderedmap.New())
+	if err != nil {
+		t.Error("fiscalChecks error", err)
+		return
+	}
+	balance, _ := floatAtPath(checks, "balance")
+	if math.Abs(balance-(-0.666e12)) > 1 {
+		t.Error("fiscalChecks balance", balance)
+	}
+	consistent, _ := checks.Get("consistent")
+	if consistent != true {
+		t.Error("fiscalChecks consistent", consistent)
+	}
+	// a reported surplus where the budget is in deficit
+	surplus, _ = budgetSurplusOrDeficit("3.4% of GDP (2017 est.)")
+	checks, _ = fiscalChecks(b.(*orderedmap.OrderedMap), surplus.(*orderedmap.OrderedMap), gdp.(*orderedmap.OrderedMap), orderedmap.New())
+	consistent, _ = checks.Get("consistent")
+	if consistent != false {
+		t.Error("fiscalChecks inconsistent", consistent)
+	}
+}
+
+func TestFiscalChecksInOtherCurrencies(t *testing.T) {
+	b, _ := budget("revenues: €600 billion\nexpenditures: €660 billion (2017 est.)")
+	surplus, _ := budgetSurplusOrDeficit("-2.6% of GDP (2017 est.)")
+	gdp, _ := gdpOfficialExchangeRate("$2.6 trillion (2017 est.)")
+	rates, _ := exchangeRates("euros (EUR) per US dollar -\n0.885 (2017 est.)\n0.903 (2016 est.)")
+	checks, err := fiscalChecks(b.(*orderedmap.OrderedMap), surplus.(*orderedmap.OrderedMap), gdp.(*orderedmap.OrderedMap), rates.(*orderedmap.OrderedMap))
+	if err != nil {
+		t.Error("fiscalChecks in euros error", err)
+		return
+	}
+	rate, _ := floatAtPath(checks, "exchange_rate")
+	if rate != 0.885 {
+		t.Error("fiscalChecks in euros exchange rate", rate)
+	}
+	consistent, _ := checks.Get("consistent")
+	if consistent != true {
+		t.Error("fiscalChecks in euros consistent", consistent)
+	}
+	// no rate for the currency
+	checks, _ = fiscalChecks(b.(*orderedmap.OrderedMap), surplus.(*orderedmap.OrderedMap), gdp.(*orderedmap.OrderedMap), orderedmap.New())
+	if _, exists := checks.Get("consistent"); exists {
+		t.Error("fiscalChecks without exchange rate should not be checked")
+	}
+}
+
+func TestFiscal(t *testing.T) {
+	economy := orderedmap.New()
+	fy, _ := fiscalYear("1 October - 30 September")
+	economy.Set("fiscal_year", fy)
+	b, _ := budget("revenues: $3.315 trillion\nexpenditures: $3.981 trillion (2017 est.)")
+	economy.Set("budget", b)
+	surplus, _ := budgetSurplusOrDeficit("-3.4% of GDP (2017 est.)")
+	economy.Set("budget_surplus_or_deficit", surplus)
+	gdp := orderedmap.New()
+	official, _ := gdpOfficialExchangeRate("$19.36 trillion (2017 est.)")
+	gdp.Set("official_exchange_rate", official)
+	economy.Set("gdp", gdp)
+	o, err := fiscal(economy)
+	if err != nil {
+		t.Error("fiscal error", err)
+		return
+	}
+	// fields are referenced rather than copied
+	fields, _ := o.Get("fields")
+	if len(fields.([]string)) != 2 || fields.([]string)[0] != "budget" {
+		t.Error("fiscal fields", fields)
+	}
+	if _, exists := o.Get("budget"); exists {
+		t.Error("fiscal should not copy the budget")
+	}
+	period, _ := o.Get("period")
+	start, _ := period.(*orderedmap.OrderedMap).Get("start")
+	if start != "2016-10-01" {
+		t.Error("fiscal period start", start)
+	}
+	checks, _ := o.Get("checks")
+	consistent, _ := checks.(*orderedmap.OrderedMap).Get("consistent")
+	if consistent != true {
+		t.Error("fiscal checks", consistent)
+	}
+	_, err = fiscal(orderedmap.New())
+	if err != NoValueErr {
+		t.Error("fiscal without fields should have no value")
+	}
+}
+
+func TestDateDetailToDate(t *testing.T) {
+	// malformed date details are errors rather than panics
+	o := orderedmap.New()
+	o.Set("granularity", "year")
+	o.Set("start", 2017)
+	_, err := dateDetailToDate(o)
+	if err != StringToDateErr {
+		t.Error("dateDetailToDate malformed start", err)
+	}
+	o = orderedmap.New()
+	o.Set("granularity", "fiscal_year")
+	o.Set("start_year", "2016")
+	_, err = dateDetailToDate(o)
+	if err != StringToDateErr {
+		t.Error("dateDetailToDate malformed fiscal year", err)
+	}
+}
@@ -0,0 +1,218 @@
+package country
+
+import (
+	"orderedmap"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const monthsPattern = `(January|February|March|April|May|June|July|August|September|October|November|December)`
+const weekdaysPattern = `(Monday|Tuesday|Wednesday|Thursday|Friday|Saturday|Sunday)`
+
+// eg 4 July, 4 July 1776
+var dayMonthRe = regexp.MustCompile(`^([0-9]{1,2}) ` + monthsPattern + `\b\s*(.*)$`)
+
+// eg July 14, see Iraq
+var monthDayRe = regexp.MustCompile(`^` + monthsPattern + ` ([0-9]{1,2})\b\s*(.*)$`)
+
+// eg first Monday in June, last Sunday of October
+var weekdayRuleRe = regexp.MustCompile(`^(first|second|third|fourth|last) ` + weekdaysPattern + ` (?:in|of) ` + monthsPattern + `\b\s*(.*)$`)
+
+var yearRe = regexp.MustCompile(`^[0-9]{4}$`)
+
+// A fixed or weekday date anywhere in a holiday, which ends the name
+var holidayDateRe = regexp.MustCompile(`\b(?:[0-9]{1,2} ` + monthsPattern + `\b|` + monthsPattern + ` [0-9]{1,2}\b|(?:first|second|third|fourth|last) ` + weekdaysPattern + ` (?:in|of) ` + monthsPattern + `\b)`)
+
+// eg "Independence Day, 8 October (1991) and Statehood Day, 25 June (1991)"
+var holidayAndRe = regexp.MustCompile(`,?\s+and\s+`)
+
+var weekdayOrdinals = map[string]int{
+	"first":  1,
+	"second": 2,
+	"third":  3,
+	"fourth": 4,
+	"last":   -1,
+}
+
+func monthNumber(s string) int {
+	t, err := time.Parse("January", s)
+	if err != nil {
+		return 0
+	}
+	return int(t.Month())
+}
+
+// Sets month and day for fixed dates, or rule for moveable dates, and
+// returns any text following the date.
+// Rules for a weekday in a month have the ordinal of the weekday, where -1
+// is the last weekday in the month. Other rules only have the text, eg
+// "Eid al-Fitr" which depends on the Islamic calendar.
+func setHolidayDate(h *orderedmap.OrderedMap, s string) string {
+	s = strings.TrimSpace(s)
+	m := dayMonthRe.FindStringSubmatch(s)
+	if m != nil {
+		day, _ := strconv.Atoi(m[1])
+		h.Set("month", monthNumber(m[2]))
+		h.Set("day", day)
+		return m[3]
+	}
+	m = monthDayRe.FindStringSubmatch(s)
+	if m != nil {
+		day, _ := strconv.Atoi(m[2])
+		h.Set("month", monthNumber(m[1]))
+		h.Set("day", day)
+		return m[3]
+	}
+	rule := orderedmap.New()
+	rule.Set("text", s)
+	m = weekdayRuleRe.FindStringSubmatch(s)
+	if m != nil {
+		rule.Set("text", strings.TrimSpace(strings.TrimSuffix(s, m[4])))
+		rule.Set("ordinal", weekdayOrdinals[m[1]])
+		rule.Set("weekday", m[2])
+		rule.Set("month", monthNumber(m[3]))
+		h.Set("rule", rule)
+		return m[4]
+	}
+	h.Set("rule", rule)
+	return ""
+}
+
+// Returns true if the text outside parenthesis has a date
+func holidayHasDate(s string) bool {
+	sNoPs, _ := removeParenthesis(s)
+	return holidayDateRe.MatchString(sNoPs)
+}
+
+// Splits a clause into holidays joined by "and" outside parenthesis, eg
+// "Independence Day, 8 October (1991) and Statehood Day, 25 June (1991)".
+// Parts without a date are part of the name after them, eg "Saints Cyril
+// and Methodius Day, 24 May", or of the holiday before them.
+func holidayParts(clause string) []string {
+	parts := []string{}
+	start := 0
+	for _, loc := range holidayAndRe.FindAllStringIndex(clause, -1) {
+		before := clause[start:loc[0]]
+		if strings.Count(clause[0:loc[0]], "(") != strings.Count(clause[0:loc[0]], ")") {
+			continue
+		}
+		parts = append(parts, before)
+		start = loc[1]
+	}
+	parts = append(parts, clause[start:len(clause)])
+	merged := []string{}
+	current := ""
+	for _, part := range parts {
+		if len(current) == 0 {
+			current = part
+		} else {
+			current = current + " and " + part
+		}
+		if holidayHasDate(part) {
+			merged = append(merged, current)
+			current = ""
+		}
+	}
+	if len(current) > 0 {
+		if len(merged) == 0 {
+			return []string{current}
+		}
+		merged[len(merged)-1] = merged[len(merged)-1] + " and " + current
+	}
+	return merged
+}
+
+// Returns the holiday for text such as "Independence Day, 4 July (1776)".
+// The name is the text before the date, where text after the first comma is
+// a note, eg "National Day, the anniversary of the founding of the People's
+// Republic of China, 1 October (1949)". Text in parenthesis is the year
+// commemorated or a note.
+func stringToHoliday(text string) (*orderedmap.OrderedMap, bool) {
+	h := orderedmap.New()
+	s, ps := removeParenthesis(text)
+	s = strings.TrimSpace(atLeastOneSpaceRe.ReplaceAllString(s, " "))
+	notes := []string{}
+	name := ""
+	dayStr := ""
+	loc := holidayDateRe.FindStringIndex(s)
+	if loc != nil && loc[0] > 0 {
+		nameBits := strings.Split(strings.TrimRight(s[0:loc[0]], ", "), ", ")
+		name = nameBits[0]
+		notes = append(notes, nameBits[1:len(nameBits)]...)
+		dayStr = s[loc[0]:len(s)]
+	} else {
+		sBits := strings.Split(s, ", ")
+		if len(sBits) < 2 {
+			return h, false
+		}
+		name = sBits[0]
+		dayStr = strings.Join(sBits[1:len(sBits)], ", ")
+	}
+	h.Set("name", strings.TrimSpace(name))
+	// see Curacao, year following the date
+	commemoratedYear := ""
+	rest := strings.TrimSpace(strings.TrimLeft(setHolidayDate(h, dayStr), ", "))
+	if yearRe.MatchString(rest) {
+		commemoratedYear = rest
+	} else if len(rest) > 0 {
+		notes = append(notes, rest)
+	}
+	for _, p := range ps {
+		if yearRe.MatchString(strings.TrimSpace(p)) {
+			commemoratedYear = strings.TrimSpace(p)
+		} else {
+			notes = append(notes, p)
+		}
+	}
+	if len(commemoratedYear) > 0 {
+		year, _ := strconv.Atoi(commemoratedYear)
+		h.Set("commemorated_year", year)
+	}
+	if len(notes) > 0 {
+		h.Set("note", strings.Join(notes, "; "))
+	}
+	return h, true
+}
+
+// Returns the holidays in the field. Holidays are separated by new lines,
+// semicolons outside parenthesis or "and". A note is the rest of the field
+// and belongs to the holiday before it, eg "National Day, 16 December
+// (1971); note - 15 August 1971 is the date of independence from the UK".
+func stringToNationalHolidays(value string) ([]*orderedmap.OrderedMap, error) {
+	holidays := []*orderedmap.OrderedMap{}
+	clauses := []string{}
+	for _, line := range strings.Split(value, "\n") {
+		for _, clause := range splitIgnoringParenthesis(line, ';') {
+			clause = strings.TrimSpace(clause)
+			if len(clause) > 0 {
+				clauses = append(clauses, clause)
+			}
+		}
+	}
+	for i, clause := range clauses {
+		if transportNoteRe.MatchString(clause) {
+			note := transportNoteRe.ReplaceAllString(strings.Join(clauses[i:len(clauses)], "; "), "")
+			if len(holidays) > 0 {
+				h := holidays[len(holidays)-1]
+				existing, hasNote := h.Get("note")
+				if hasNote {
+					note = existing.(string) + "; " + note
+				}
+				h.Set("note", note)
+			}
+			break
+		}
+		for _, part := range holidayParts(clause) {
+			h, isHoliday := stringToHoliday(part)
+			if isHoliday {
+				holidays = append(holidays, h)
+			}
+		}
+	}
+	if len(holidays) == 0 {
+		return holidays, NoValueErr
+	}
+	return holidays, nil
+}
@@ -0,0 +1,258 @@
+package country
+
+import (
+	"orderedmap"
+	"testing"
+)
+
+type NationalHolidayCase struct {
+	s        string
+	expected []map[string]interface{}
+}
+
+var nationalHolidayCases = []NationalHolidayCase{
+	// day then month, eg United States
+	NationalHolidayCase{
+		s: "Independence Day, 4 July (1776)",
+		expected: []map[string]interface{}{
+			map[string]interface{}{
+				"name":              "Independence Day",
+				"month":             7,
+				"day":               4,
+				"commemorated_year": 1776,
+			},
+		},
+	},
+	// month then day, see Iraq
+	NationalHolidayCase{
+		s: "Republic Day, July 14 (1958)",
+		expected: []map[string]interface{}{
+			map[string]interface{}{
+				"name":              "Republic Day",
+				"month":             7,
+				"day":               14,
+				"commemorated_year": 1958,
+			},
+		},
+	},
+	// year following the date, see Curacao
+	NationalHolidayCase{
+		s: "King's Day, 27 April 1967",
+		expected: []map[string]interface{}{
+			map[string]interface{}{
+				"name":              "King's Day",
+				"month":             4,
+				"day":               27,
+				"commemorated_year": 1967,
+			},
+		},
+	},
+	// weekday rule with a note, see Samoa
+	NationalHolidayCase{
+		s: "Queen's Birthday, first Monday in June (Queen Elizabeth II)",
+		expected: []map[string]interface{}{
+			map[string]interface{}{
+				"name":    "Queen's Birthday",
+				"ordinal": 1,
+				"weekday": "Monday",
+				"rule":    "first Monday in June",
+				"note":    "Queen Elizabeth II",
+			},
+		},
+	},
+	// rule which can not be calculated and multiple holidays
+	NationalHolidayCase{
+		s: "Independence Day, 15 August (1947); Eid al-Fitr, end of Ramadan",
+		expected: []map[string]interface{}{
+			map[string]interface{}{
+				"name":              "Independence Day",
+				"month":             8,
+				"day":               15,
+				"commemorated_year": 1947,
+			},
+			map[string]interface{}{
+				"name": "Eid al-Fitr",
+				"rule": "end of Ramadan",
+			},
+		},
+	},
+	// note for the holiday before it, see Bahrain
+	NationalHolidayCase{
+		s: "National Day, 16 December (1971); note - 15 August 1971 is the date of independence from the UK, 16 December 1971 is the date of independence from British protection",
+		expected: []map[string]interface{}{
+			map[string]interface{}{
+				"name":              "National Day",
+				"month":             12,
+				"day":               16,
+				"commemorated_year": 1971,
+				"note":              "15 August 1971 is the date of independence from the UK, 16 December 1971 is the date of independence from British protection",
+			},
+		},
+	},
+	// a holiday on each line, see Bosnia and Herzegovina
+	NationalHolidayCase{
+		s: "Independence Day, 1 March (1992)\nStatehood Day, 25 November (1943) (observed in the Federation of Bosnia and Herzegovina)\nRepublika Srpska Day, 9 January (1992) (observed in the Republika Srpska)",
+		expected: []map[string]interface{}{
+			map[string]interface{}{
+				"name":              "Independence Day",
+				"month":             3,
+				"day":               1,
+				"commemorated_year": 1992,
+			},
+			map[string]interface{}{
+				"name":              "Statehood Day",
+				"month":             11,
+				"day":               25,
+				"commemorated_year": 1943,
+				"note":              "observed in the Federation of Bosnia and Herzegovina",
+			},
+			map[string]interface{}{
+				"name":              "Republika Srpska Day",
+				"month":             1,
+				"day":               9,
+				"commemorated_year": 1992,
+				"note":              "observed in the Republika Srpska",
+			},
+		},
+	},
+	// description between the name and the date, see China
+	NationalHolidayCase{
+		s: "National Day, the anniversary of the founding of the People's Republic of China, 1 October (1949)",
+		expected: []map[string]interface{}{
+			map[string]interface{}{
+				"name":              "National Day",
+				"month":             10,
+				"day":               1,
+				"commemorated_year": 1949,
+				"note":              "the anniversary of the founding of the People's Republic of China",
+			},
+		},
+	},
+	// holidays joined by and, with a note of several sentences, see Croatia
+	NationalHolidayCase{
+		s: "Independence Day, 8 October (1991) and Statehood Day, 25 June (1991); note - 25 June 1991 is the day the Croatian parliament voted for independence; following a three-month moratorium, the decision was implemented on 8 October 1991",
+		expected: []map[string]interface{}{
+			map[string]interface{}{
+				"name":              "Independence Day",
+				"month":             10,
+				"day":               8,
+				"commemorated_year": 1991,
+			},
+			map[string]interface{}{
+				"name":              "Statehood Day",
+				"month":             6,
+				"day":               25,
+				"commemorated_year": 1991,
+				"note":              "25 June 1991 is the day the Croatian parliament voted for independence; following a three-month moratorium, the decision was implemented on 8 October 1991",
+			},
+		},
+	},
+	// holidays joined by a comma and and, see Liechtenstein
+	NationalHolidayCase{
+		s: "Assumption Day, 15 August, and National Day, 15 August",
+		expected: []map[string]interface{}{
+			map[string]interface{}{
+				"name":  "Assumption Day",
+				"month": 8,
+				"day":   15,
+			},
+			map[string]interface{}{
+				"name":  "National Day",
+				"month": 8,
+				"day":   15,
+			},
+		},
+	},
+	// parenthesis between the name and the date without a comma, see
+	// Luxembourg
+	NationalHolidayCase{
+		s: "National Day (Birthday of Grand Duchess Charlotte) 23 June (1896); note - the actual birthday of Grand Duke Henri is 16 April (1955)",
+		expected: []map[string]interface{}{
+			map[string]interface{}{
+				"name":              "National Day",
+				"month":             6,
+				"day":               23,
+				"commemorated_year": 1896,
+				"note":              "Birthday of Grand Duchess Charlotte; the actual birthday of Grand Duke Henri is 16 April (1955)",
+			},
+		},
+	},
+	// year after the date followed by parenthesis, see Paraguay
+	NationalHolidayCase{
+		s: "Independence Day, 14 May 1811 (observed 15 May)",
+		expected: []map[string]interface{}{
+			map[string]interface{}{
+				"name":              "Independence Day",
+				"month":             5,
+				"day":               14,
+				"commemorated_year": 1811,
+				"note":              "observed 15 May",
+			},
+		},
+	},
+	// see European Union
+	NationalHolidayCase{
+		s: "Europe Day (also known as Schuman Day) 9 May (1950); note - the day in 1950 that Robert Schuman proposed the creation of what would become the European Union",
+		expected: []map[string]interface{}{
+			map[string]interface{}{
+				"name":              "Europe Day",
+				"month":             5,
+				"day":               9,
+				"commemorated_year": 1950,
+				"note":              "also known as Schuman Day; the day in 1950 that Robert Schuman proposed the creation of what would become the European Union",
+			},
+		},
+	},
+	// and in a name
+	NationalHolidayCase{
+		s: "Saints Cyril and Methodius Day, 24 May",
+		expected: []map[string]interface{}{
+			map[string]interface{}{
+				"name":  "Saints Cyril and Methodius Day",
+				"month": 5,
+				"day":   24,
+			},
+		},
+	},
+}
+
+func TestNationalHoliday(t *testing.T) {
+	for testIndex, c := range nationalHolidayCases {
+		v, err := nationalHoliday(c.s)
+		if err != nil {
+			t.Error("nationalHoliday error, testIndex: ", testIndex, err)
+			continue
+		}
+		holidays := v.([]*orderedmap.OrderedMap)
+		if len(holidays) != len(c.expected) {
+			t.Error("nationalHoliday length, testIndex: ", testIndex, len(holidays))
+			continue
+		}
+		for i, expected := range c.expected {
+			h := holidays[i]
+			for key, expectedValue := range expected {
+				var value interface{}
+				var exists bool
+				switch key {
+				case "rule":
+					value, exists = ruleValue(h, "text")
+				case "ordinal", "weekday":
+					value, exists = ruleValue(h, key)
+				default:
+					value, exists = h.Get(key)
+				}
+				if !exists || value != expectedValue {
+					t.Error("nationalHoliday", key, "testIndex: ", testIndex, value)
+				}
+			}
+		}
+	}
+}
+
+func ruleValue(h *orderedmap.OrderedMap, key string) (interface{}, bool) {
+	rule, exists := h.Get("rule")
+	if !exists {
+		return nil, false
+	}
+	return rule.(*orderedmap.OrderedMap).Get(key)
+}
@@ -0,0 +1,96 @@
+package country
+
+import (
+	"errors"
+	"orderedmap"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var StringToManpowerGroupErr = errors.New("String is not a manpower group")
+
+// eg "males age 16-49", "females age 18-49", "male" and, from older weekly
+// files, "males_age_16_49"
+var manpowerGroupRe = regexp.MustCompile(`^(males?|females?|men|women)(?:\s+aged?)?(?:\s+([0-9]+)\s*(?:-|to|\s)\s*([0-9]+))?$`)
+
+// ManpowerGroup is the sex and age band of a manpower count. Counts of
+// people reaching military age annually have no age band.
+type ManpowerGroup struct {
+	Sex     string
+	MinAge  int
+	MaxAge  int
+	HasAges bool
+}
+
+// Converts a key of the manpower fields to a group, eg "males age 16-49"
+func StringToManpowerGroup(s string) (ManpowerGroup, error) {
+	g := ManpowerGroup{}
+	s = strings.TrimSpace(strings.ToLower(strings.Replace(s, "_", " ", -1)))
+	m := manpowerGroupRe.FindStringSubmatch(s)
+	if m == nil {
+		return g, StringToManpowerGroupErr
+	}
+	g.Sex = "male"
+	if strings.HasPrefix(m[1], "f") || m[1] == "women" {
+		g.Sex = "female"
+	}
+	if len(m[2]) > 0 {
+		g.MinAge, _ = strconv.Atoi(m[2])
+		g.MaxAge, _ = strconv.Atoi(m[3])
+		g.HasAges = true
+	}
+	return g, nil
+}
+
+// Returns the count for each sex and age band, eg "males age 16-49:
+// 73,270,043\nfemales age 16-49: 71,000,322 (2010 est.)". The date applies
+// to every count.
+func stringToManpower(value string) (*orderedmap.OrderedMap, error) {
+	o := orderedmap.New()
+	value, date, hasDate := stringWithoutDateDetail(value)
+	bySex := []*orderedmap.OrderedMap{}
+	notes := []string{}
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		bits := strings.SplitN(line, ":", 2)
+		if len(bits) != 2 {
+			notes = append(notes, line)
+			continue
+		}
+		g, err := StringToManpowerGroup(bits[0])
+		if err != nil {
+			notes = append(notes, line)
+			continue
+		}
+		count, err := stringToNumericValue(strings.TrimSpace(bits[1]))
+		if err != nil {
+			continue
+		}
+		record := orderedmap.New()
+		record.Set("sex", g.Sex)
+		if g.HasAges {
+			record.Set("min_age", g.MinAge)
+			record.Set("max_age", g.MaxAge)
+		}
+		record.Set("count", count.lowerBound)
+		setNumericQualifiers(record, count)
+		bySex = append(bySex, record)
+	}
+	if len(bySex) > 0 {
+		o.Set("by_sex", bySex)
+	}
+	if len(notes) > 0 {
+		o.Set("note", strings.Join(notes, "; "))
+	}
+	if len(o.Keys()) == 0 {
+		return o, NoValueErr
+	}
+	if hasDate {
+		setDate(o, date)
+	}
+	return o, nil
+}
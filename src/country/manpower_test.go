@@ -0,0 +1,58 @@
+package country
+
+import (
+	"orderedmap"
+	"testing"
+)
+
+type ManpowerGroupCase struct {
+	s               string
+	expectedSex     string
+	expectedMinAge  int
+	expectedMaxAge  int
+	expectedHasAges bool
+}
+
+var manpowerGroupCases = []ManpowerGroupCase{
+	ManpowerGroupCase{"males age 16-49", "male", 16, 49, true},
+	ManpowerGroupCase{"females age 18-49", "female", 18, 49, true},
+	// reaching militarily significant age annually
+	ManpowerGroupCase{"male", "male", 0, 0, false},
+	// weekly files created before the groups were typed
+	ManpowerGroupCase{"females_age_16_49", "female", 16, 49, true},
+}
+
+func TestStringToManpowerGroup(t *testing.T) {
+	for testIndex, c := range manpowerGroupCases {
+		g, err := StringToManpowerGroup(c.s)
+		if err != nil {
+			t.Error("StringToManpowerGroup error, testIndex: ", testIndex, err)
+			continue
+		}
+		if g.Sex != c.expectedSex || g.MinAge != c.expectedMinAge || g.MaxAge != c.expectedMaxAge || g.HasAges != c.expectedHasAges {
+			t.Error("StringToManpowerGroup, testIndex: ", testIndex, g)
+		}
+	}
+}
+
+func TestStringToManpower(t *testing.T) {
+	o, err := stringToManpower("males age 16-49: 73,270,043\nfemales age 16-49: 71,000,322 (2010 est.)")
+	if err != nil {
+		t.Error("stringToManpower error", err)
+		return
+	}
+	bySex, _ := o.Get("by_sex")
+	records := bySex.([]*orderedmap.OrderedMap)
+	if len(records) != 2 {
+		t.Error("stringToManpower records", len(records))
+		return
+	}
+	count, _ := records[1].Get("count")
+	if count != 71000322.0 {
+		t.Error("stringToManpower count", count)
+	}
+	date, _ := o.Get("date")
+	if date != "2010" {
+		t.Error("stringToManpower date", date)
+	}
+}
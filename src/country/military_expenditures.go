@@ -0,0 +1,149 @@
+package country
+
+import (
+	"orderedmap"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var expenditurePercentRe = regexp.MustCompile(`(-?[0-9]+(?:\.[0-9]+)?)\s*%`)
+
+// eg $370.7 billion, $40 million
+var expenditureAmountRe = regexp.MustCompile(`\$\s*[0-9][0-9,]*(?:\.[0-9]+)?(?:\s*(?:thousand|million|billion|trillion))?`)
+
+// eg "dollar figure: " and "percent of GDP: " in older layouts
+var expenditureKeyRe = regexp.MustCompile(`^[a-zA-Z ]+(?::|\s-)\s*`)
+
+// militaryExpenditure is the spending for one year, as percent of GDP, an
+// amount in USD or both
+type militaryExpenditure struct {
+	percent    float64
+	hasPercent bool
+	amount     float64
+	hasAmount  bool
+	date       factbookDate
+	hasDate    bool
+	isEstimate bool
+}
+
+func (e militaryExpenditure) toMap() *orderedmap.OrderedMap {
+	o := orderedmap.New()
+	if e.hasPercent {
+		o.Set("percent_of_gdp", e.percent)
+	}
+	if e.hasAmount {
+		amount := orderedmap.New()
+		amount.Set("value", e.amount)
+		amount.Set("units", "USD")
+		setCanonicalValue(amount, e.amount, "USD")
+		o.Set("amount", amount)
+	}
+	if e.isEstimate {
+		o.Set("is_estimate", true)
+	}
+	if e.hasDate {
+		setDate(o, e.date)
+	}
+	return o
+}
+
+// Returns the expenditure in a line, eg "4.82% of GDP (2020 est.)
+// (approximately $6.2 billion)" or, in older layouts, "dollar figure:
+// $370.7 billion (FY04 est.)"
+func stringToMilitaryExpenditure(line string) (militaryExpenditure, bool) {
+	e := militaryExpenditure{}
+	e.isEstimate = isEstimateStr(line)
+	line, e.date, e.hasDate = stringWithoutDateDetail(line)
+	line = expenditureKeyRe.ReplaceAllString(line, "")
+	amountStr := expenditureAmountRe.FindString(line)
+	if len(amountStr) > 0 {
+		amount, err := stringToPlainNumber(amountStr)
+		if err == nil {
+			e.amount = amount
+			e.hasAmount = true
+		}
+		// so the amount is not read as a percent, eg $6.2 billion, 3.8%
+		line = strings.Replace(line, amountStr, "", 1)
+	}
+	m := expenditurePercentRe.FindStringSubmatch(line)
+	if m != nil {
+		e.percent, _ = strconv.ParseFloat(m[1], 64)
+		e.hasPercent = true
+	}
+	return e, e.hasPercent || e.hasAmount
+}
+
+// Military expenditures have a line for each year. Lines for the same year,
+// eg a dollar figure and a percent of GDP, are combined.
+func stringToMilitaryExpenditures(value string) (*orderedmap.OrderedMap, error) {
+	o := orderedmap.New()
+	expenditures := []militaryExpenditure{}
+	indexForDate := map[string]int{}
+	notes := []string{}
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if startsWith(line, "country comparison to the world") {
+			bits := strings.SplitN(line, ":", 2)
+			if len(bits) < 2 {
+				continue
+			}
+			rank, err := stringToNumericValue(strings.TrimSpace(bits[1]))
+			if err == nil {
+				o.Set("global_rank", rank.lowerBound)
+			}
+			continue
+		}
+		if startsWith(line, "note") {
+			notes = append(notes, transportNoteRe.ReplaceAllString(line, ""))
+			continue
+		}
+		// eg NA, or NA (2010) for a year without figures
+		lineNoDate, _, _ := stringWithoutDateDetail(line)
+		if lineNoDate == "NA" {
+			continue
+		}
+		e, isExpenditure := stringToMilitaryExpenditure(line)
+		if !isExpenditure {
+			notes = append(notes, line)
+			continue
+		}
+		if !e.hasDate {
+			expenditures = append(expenditures, e)
+			continue
+		}
+		i, exists := indexForDate[e.date.String()]
+		if !exists {
+			indexForDate[e.date.String()] = len(expenditures)
+			expenditures = append(expenditures, e)
+			continue
+		}
+		existing := &expenditures[i]
+		if e.hasPercent && !existing.hasPercent {
+			existing.percent = e.percent
+			existing.hasPercent = true
+		}
+		if e.hasAmount && !existing.hasAmount {
+			existing.amount = e.amount
+			existing.hasAmount = true
+		}
+		existing.isEstimate = existing.isEstimate || e.isEstimate
+	}
+	if len(expenditures) > 0 {
+		annualValues := []*orderedmap.OrderedMap{}
+		for _, e := range expenditures {
+			annualValues = append(annualValues, e.toMap())
+		}
+		o.Set("annual_values", annualValues)
+	}
+	if len(notes) > 0 {
+		o.Set("note", strings.Join(notes, "; "))
+	}
+	if len(o.Keys()) == 0 {
+		return o, NoValueErr
+	}
+	return o, nil
+}
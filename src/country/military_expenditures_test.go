@@ -0,0 +1,82 @@
+package country
+
+import (
+	"orderedmap"
+	"testing"
+)
+
+type MilitaryExpenditureCase struct {
+	s                 string
+	expectedYears     int
+	expectedPercent   interface{}
+	expectedAmount    interface{}
+	expectedFirstDate string
+	expectedError     error
+}
+
+var militaryExpenditureCases = []MilitaryExpenditureCase{
+	MilitaryExpenditureCase{
+		s:                 "3.29% of GDP (2016)\n3.29% of GDP (2015)\n3.51% of GDP (2014)\ncountry comparison to the world: 22",
+		expectedYears:     3,
+		expectedPercent:   3.29,
+		expectedFirstDate: "2016",
+	},
+	MilitaryExpenditureCase{
+		s:                 "4.82% of GDP (2020 est.) (approximately $6.2 billion)\n5.1% of GDP (2019)",
+		expectedYears:     2,
+		expectedPercent:   4.82,
+		expectedAmount:    6.2e9,
+		expectedFirstDate: "2020",
+	},
+	// older layout with the dollar figure and percent of GDP on separate lines
+	MilitaryExpenditureCase{
+		s:                 "dollar figure: $370.7 billion (2003)\npercent of GDP: 3.3% (2003)",
+		expectedYears:     1,
+		expectedPercent:   3.3,
+		expectedAmount:    370.7e9,
+		expectedFirstDate: "2003",
+	},
+	// older layout with fiscal years, which are different years for the
+	// dollar figure and the percent of GDP
+	MilitaryExpenditureCase{
+		s:                 "dollar figure: $370.7 billion (FY04 est.)\npercent of GDP: 3.3% (FY03 est.)",
+		expectedYears:     2,
+		expectedAmount:    370.7e9,
+		expectedFirstDate: "2004",
+	},
+	MilitaryExpenditureCase{
+		s:             "NA",
+		expectedError: NoValueErr,
+	},
+}
+
+func TestStringToMilitaryExpenditures(t *testing.T) {
+	for testIndex, c := range militaryExpenditureCases {
+		o, err := stringToMilitaryExpenditures(c.s)
+		if err != c.expectedError {
+			t.Error("stringToMilitaryExpenditures error, testIndex: ", testIndex, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		v, _ := o.Get("annual_values")
+		values := v.([]*orderedmap.OrderedMap)
+		if len(values) != c.expectedYears {
+			t.Error("stringToMilitaryExpenditures years, testIndex: ", testIndex, len(values))
+			continue
+		}
+		percent, _ := values[0].Get("percent_of_gdp")
+		if percent != c.expectedPercent {
+			t.Error("stringToMilitaryExpenditures percent, testIndex: ", testIndex, percent)
+		}
+		amount, _ := floatAtPath(values[0], "amount", "value")
+		if c.expectedAmount != nil && amount != c.expectedAmount {
+			t.Error("stringToMilitaryExpenditures amount, testIndex: ", testIndex, amount)
+		}
+		date, _ := values[0].Get("date")
+		if date != c.expectedFirstDate {
+			t.Error("stringToMilitaryExpenditures date, testIndex: ", testIndex, date)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package country
+
+import (
+	"orderedmap"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// eg 18-49 years of age, 18 years of age
+var militaryAgeRangeRe = regexp.MustCompile(`\b([0-9]{2})\s*-\s*([0-9]{2}) years of age`)
+var militaryAgeRe = regexp.MustCompile(`\b([0-9]{2}) years of age`)
+var militaryAnyAgeRe = regexp.MustCompile(`\b([0-9]{2})(?:\s*-\s*([0-9]{2}))? years of age`)
+
+// A part of the field starts a new rule when it has an age and says who or
+// what the age is for, eg "18 years of age for women", see Norway
+var militaryServiceWordRe = regexp.MustCompile(`\b(?:service|conscript\w*|volunteers?|voluntary|compulsory|mandatory|enlist\w*)\b`)
+var militaryCompulsoryRe = regexp.MustCompile(`\b(?:compulsory|mandatory|conscript|conscripts|conscripted)\b`)
+var militaryVoluntaryRe = regexp.MustCompile(`\b(?:voluntary|volunteers?)\b`)
+var militaryMaleRe = regexp.MustCompile(`\b(?:males?|men)\b`)
+var militaryFemaleRe = regexp.MustCompile(`\b(?:females?|women)\b`)
+var militaryBothSexesRe = regexp.MustCompile(`\b(?:both sexes|men and women|male and female)\b`)
+var conscriptionAbolishedRe = regexp.MustCompile(`\bno conscription\b|\bconscription (?:was |has been )?(?:abolished|ended|suspended)\b|\babolished conscription\b`)
+
+// eg 16-month, 19 months, 7-23 months, 1-year, 8 years
+var serviceDurationRe = regexp.MustCompile(`\b([0-9]+(?:\.[0-9]+)?)(?:\s*-\s*([0-9]+(?:\.[0-9]+)?))?[\s-]*(month|year)s?\b`)
+
+// militaryServiceRule is the age and obligation for one type of service,
+// eg compulsory service for men. Sexes is empty when the field does not say
+// who the rule applies to.
+type militaryServiceRule struct {
+	service               string
+	minAge                int
+	maxAge                int
+	hasMaxAge             bool
+	sexes                 []string
+	obligationMonths      float64
+	obligationMaxMonths   float64
+	hasObligation         bool
+	hasObligationRange    bool
+	conscriptionAbolished bool
+	text                  []string
+}
+
+func (r militaryServiceRule) toMap() *orderedmap.OrderedMap {
+	o := orderedmap.New()
+	if len(r.service) > 0 {
+		o.Set("service", r.service)
+	}
+	o.Set("min_age", r.minAge)
+	if r.hasMaxAge {
+		o.Set("max_age", r.maxAge)
+	}
+	if len(r.sexes) > 0 {
+		o.Set("sexes", r.sexes)
+	}
+	if r.hasObligation {
+		o.Set("obligation_months", r.obligationMonths)
+	}
+	if r.hasObligationRange {
+		o.Set("obligation_max_months", r.obligationMaxMonths)
+	}
+	o.Set("conscription_abolished", r.conscriptionAbolished)
+	o.Set("text", strings.Join(r.text, "; "))
+	return o
+}
+
+func militarySexes(s string) []string {
+	if militaryBothSexesRe.MatchString(s) {
+		return []string{"male", "female"}
+	}
+	sexes := []string{}
+	if militaryMaleRe.MatchString(s) {
+		sexes = append(sexes, "male")
+	}
+	if militaryFemaleRe.MatchString(s) {
+		sexes = append(sexes, "female")
+	}
+	return sexes
+}
+
+// Returns the types of service in s, in the order compulsory, voluntary
+func militaryServiceTypes(s string) []string {
+	services := []string{}
+	if militaryCompulsoryRe.MatchString(s) {
+		services = append(services, "compulsory")
+	}
+	if militaryVoluntaryRe.MatchString(s) {
+		services = append(services, "voluntary")
+	}
+	return services
+}
+
+// serviceDuration is a service obligation, with the sexes named in the text
+// following it, eg "32 months for enlisted men"
+type serviceDuration struct {
+	months    float64
+	maxMonths float64
+	hasMax    bool
+	sexes     []string
+}
+
+func durationMonths(number, unit string) float64 {
+	n, _ := strconv.ParseFloat(number, 64)
+	if unit == "year" {
+		return n * 12
+	}
+	return n
+}
+
+// Returns the durations of the service obligation in s. Durations after the
+// word obligation are preferred, eg "service obligation 8 years, including
+// 2-5 years active duty" is 96 months, otherwise the last duration before
+// it is used, eg "16-month conscript service obligation".
+func serviceDurations(s string) []serviceDuration {
+	obligationIndex := strings.Index(s, "obligation")
+	if obligationIndex == -1 {
+		return []serviceDuration{}
+	}
+	after := s[obligationIndex:len(s)]
+	matches := serviceDurationRe.FindAllStringSubmatchIndex(after, -1)
+	text := after
+	if len(matches) == 0 {
+		before := serviceDurationRe.FindAllStringSubmatchIndex(s[0:obligationIndex], -1)
+		if len(before) == 0 {
+			return []serviceDuration{}
+		}
+		matches = before[len(before)-1 : len(before)]
+		text = s
+	}
+	durations := []serviceDuration{}
+	for i, m := range matches {
+		unit := text[m[6]:m[7]]
+		d := serviceDuration{
+			months: durationMonths(text[m[2]:m[3]], unit),
+		}
+		if m[4] > -1 {
+			d.maxMonths = durationMonths(text[m[4]:m[5]], unit)
+			d.hasMax = true
+		}
+		contextEnd := len(text)
+		if i+1 < len(matches) {
+			contextEnd = matches[i+1][0]
+		}
+		d.sexes = militarySexes(text[m[1]:contextEnd])
+		durations = append(durations, d)
+	}
+	return durations
+}
+
+func (r *militaryServiceRule) setObligation(d serviceDuration) {
+	r.obligationMonths = d.months
+	r.hasObligation = true
+	r.obligationMaxMonths = d.maxMonths
+	r.hasObligationRange = d.hasMax
+}
+
+// Sets the obligation on the rules. When the durations differ by sex, eg
+// "32 months for enlisted men and about 24 months for enlisted women", a
+// rule for both sexes is split into a rule for each sex.
+func applyServiceDurations(rules []*militaryServiceRule, durations []serviceDuration) []*militaryServiceRule {
+	if len(durations) == 0 {
+		return rules
+	}
+	bySex := map[string]serviceDuration{}
+	for _, d := range durations {
+		if len(d.sexes) == 1 {
+			if _, exists := bySex[d.sexes[0]]; !exists {
+				bySex[d.sexes[0]] = d
+			}
+		}
+	}
+	split := []*militaryServiceRule{}
+	for _, r := range rules {
+		if len(bySex) < 2 {
+			d := durations[0]
+			if len(r.sexes) == 1 {
+				sexDuration, exists := bySex[r.sexes[0]]
+				if exists {
+					d = sexDuration
+				}
+			}
+			r.setObligation(d)
+			split = append(split, r)
+			continue
+		}
+		sexes := r.sexes
+		if len(sexes) == 0 {
+			sexes = []string{"male", "female"}
+		}
+		for _, sex := range sexes {
+			sexRule := *r
+			sexRule.sexes = []string{sex}
+			d, exists := bySex[sex]
+			if exists {
+				sexRule.setObligation(d)
+			}
+			split = append(split, &sexRule)
+		}
+	}
+	return split
+}
+
+// militaryAge is an age range and the sexes it applies to
+type militaryAge struct {
+	minAge    int
+	maxAge    int
+	hasMaxAge bool
+	sexes     []string
+}
+
+// Returns the ages in s. When every age follows its own sex, eg "men 18-27
+// years of age and women 18-23 years of age", each age is paired with that
+// sex. Otherwise s has one age, preferring a range, for the sexes named
+// anywhere in s.
+func militaryAges(s string) []militaryAge {
+	matches := militaryAnyAgeRe.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) > 1 {
+		ages := []militaryAge{}
+		previousEnd := 0
+		for _, m := range matches {
+			a := militaryAge{
+				sexes: militarySexes(s[previousEnd:m[0]]),
+			}
+			if len(a.sexes) == 0 {
+				break
+			}
+			a.minAge, _ = strconv.Atoi(s[m[2]:m[3]])
+			if m[4] > -1 {
+				a.maxAge, _ = strconv.Atoi(s[m[4]:m[5]])
+				a.hasMaxAge = true
+			}
+			ages = append(ages, a)
+			previousEnd = m[1]
+		}
+		if len(ages) == len(matches) {
+			return ages
+		}
+	}
+	m := militaryAgeRangeRe.FindStringSubmatch(s)
+	if m != nil {
+		minAge, _ := strconv.Atoi(m[1])
+		maxAge, _ := strconv.Atoi(m[2])
+		return []militaryAge{militaryAge{minAge, maxAge, true, militarySexes(s)}}
+	}
+	m = militaryAgeRe.FindStringSubmatch(s)
+	if m != nil {
+		minAge, _ := strconv.Atoi(m[1])
+		return []militaryAge{militaryAge{minAge, 0, false, militarySexes(s)}}
+	}
+	return []militaryAge{}
+}
+
+// Splits the field into rules, where each rule starts with an age, eg
+// "18-27 years of age for compulsory or voluntary military service" is a
+// rule for compulsory service and a rule for voluntary service. Parts of the
+// field without an age add to the rules before them, eg a service obligation
+// applies to every rule before it without an obligation.
+func stringToMilitaryServiceRules(value string) []*militaryServiceRule {
+	abolished := conscriptionAbolishedRe.MatchString(value)
+	rules := []*militaryServiceRule{}
+	// the rules started by the most recent part with an age
+	currentStart := 0
+	previousServices := []string{}
+	for _, part := range strings.Split(value, ";") {
+		part = strings.TrimSpace(part)
+		if len(part) == 0 {
+			continue
+		}
+		ages := militaryAges(part)
+		// a part with an age but no service or sex only starts a rule when
+		// there are no rules yet, eg "18 years of age"
+		startsRule := len(ages) > 0 && (militaryServiceWordRe.MatchString(part) || len(ages[0].sexes) > 0 || len(rules) == 0)
+		if startsRule {
+			currentStart = len(rules)
+			services := militaryServiceTypes(part)
+			if len(services) == 0 {
+				services = previousServices
+			}
+			if len(services) == 0 {
+				services = []string{""}
+			}
+			for _, service := range services {
+				for _, a := range ages {
+					rules = append(rules, &militaryServiceRule{
+						service:               service,
+						minAge:                a.minAge,
+						maxAge:                a.maxAge,
+						hasMaxAge:             a.hasMaxAge,
+						sexes:                 a.sexes,
+						conscriptionAbolished: abolished,
+						text:                  []string{part},
+					})
+				}
+			}
+			previousServices = services
+		} else {
+			for _, r := range rules[currentStart:len(rules)] {
+				r.text = append(r.text, part)
+				if len(r.sexes) == 0 && militaryBothSexesRe.MatchString(part) {
+					r.sexes = []string{"male", "female"}
+				}
+			}
+		}
+		durations := serviceDurations(part)
+		if len(durations) == 0 {
+			continue
+		}
+		// a conscript obligation is only for compulsory service
+		conscriptOnly := militaryCompulsoryRe.MatchString(part) && !startsRule
+		updated := []*militaryServiceRule{}
+		updatedStart := len(rules)
+		for i, r := range rules {
+			if i == currentStart {
+				updatedStart = len(updated)
+			}
+			applies := i >= currentStart
+			if !startsRule {
+				applies = !r.hasObligation && (!conscriptOnly || r.service == "compulsory")
+			}
+			if !applies {
+				updated = append(updated, r)
+				continue
+			}
+			updated = append(updated, applyServiceDurations([]*militaryServiceRule{r}, durations)...)
+		}
+		// rules may be split by sex, see applyServiceDurations
+		if updatedStart < len(rules) {
+			currentStart = updatedStart
+		}
+		rules = updated
+	}
+	return rules
+}
@@ -0,0 +1,117 @@
+package country
+
+import (
+	"orderedmap"
+	"strings"
+	"testing"
+)
+
+type MilitaryServiceRuleCase struct {
+	service          string
+	minAge           int
+	maxAge           int
+	sexes            string
+	obligationMonths float64
+	abolished        bool
+}
+
+type MilitaryServiceCase struct {
+	s             string
+	expectedRules []MilitaryServiceRuleCase
+}
+
+var militaryServiceCases = []MilitaryServiceCase{
+	// see Eritrea
+	MilitaryServiceCase{
+		s: "18-40 years of age for male and female compulsory and voluntary military service; 16-month conscript service obligation",
+		expectedRules: []MilitaryServiceRuleCase{
+			MilitaryServiceRuleCase{"compulsory", 18, 40, "male,female", 16, false},
+			MilitaryServiceRuleCase{"voluntary", 18, 40, "male,female", 0, false},
+		},
+	},
+	// see Norway
+	MilitaryServiceCase{
+		s: "19-35 years of age for male and female selective compulsory military service; 17 years of age for male volunteers; 18 years of age for women; service obligation - 19 months",
+		expectedRules: []MilitaryServiceRuleCase{
+			MilitaryServiceRuleCase{"compulsory", 19, 35, "male,female", 19, false},
+			MilitaryServiceRuleCase{"voluntary", 17, 0, "male", 19, false},
+			MilitaryServiceRuleCase{"voluntary", 18, 0, "female", 19, false},
+		},
+	},
+	// see Israel
+	MilitaryServiceCase{
+		s: "18 years of age for compulsory (Jews, Druzes) and voluntary (Christians, Muslims, Circassians) military service; both sexes are obligated to military service; conscript service obligation - 32 months for enlisted men and about 24 months for enlisted women",
+		expectedRules: []MilitaryServiceRuleCase{
+			MilitaryServiceRuleCase{"compulsory", 18, 0, "male", 32, false},
+			MilitaryServiceRuleCase{"compulsory", 18, 0, "female", 24, false},
+			MilitaryServiceRuleCase{"voluntary", 18, 0, "male,female", 0, false},
+		},
+	},
+	// ages for each sex
+	MilitaryServiceCase{
+		s: "men 18-27 years of age and women 18-23 years of age for compulsory military service; conscript service obligation - 12 months",
+		expectedRules: []MilitaryServiceRuleCase{
+			MilitaryServiceRuleCase{"compulsory", 18, 27, "male", 12, false},
+			MilitaryServiceRuleCase{"compulsory", 18, 23, "female", 12, false},
+		},
+	},
+	// an age without a service or sex
+	MilitaryServiceCase{
+		s: "18 years of age",
+		expectedRules: []MilitaryServiceRuleCase{
+			MilitaryServiceRuleCase{"", 18, 0, "", 0, false},
+		},
+	},
+	// see France
+	MilitaryServiceCase{
+		s: "17-40 years of age for male or female voluntary military service; no conscription (abolished 2001); service obligation 1-5 years",
+		expectedRules: []MilitaryServiceRuleCase{
+			MilitaryServiceRuleCase{"voluntary", 17, 40, "male,female", 12, true},
+		},
+	},
+}
+
+func TestMilitaryServiceAgeAndObligation(t *testing.T) {
+	v, err := militaryServiceAgeAndObligation("18 years of age (2012)")
+	if err != nil {
+		t.Error("militaryServiceAgeAndObligation error", err)
+		return
+	}
+	o := v.(*orderedmap.OrderedMap)
+	age, _ := o.Get("years_of_age")
+	if age != 18 {
+		t.Error("militaryServiceAgeAndObligation years_of_age", age)
+	}
+	date, _ := o.Get("date")
+	if date != "2012" {
+		t.Error("militaryServiceAgeAndObligation date", date)
+	}
+}
+
+func TestMilitaryServiceRules(t *testing.T) {
+	for testIndex, c := range militaryServiceCases {
+		rules := stringToMilitaryServiceRules(c.s)
+		if len(rules) != len(c.expectedRules) {
+			t.Error("militaryServiceRules count, testIndex: ", testIndex, len(rules))
+			continue
+		}
+		for i, expected := range c.expectedRules {
+			r := rules[i]
+			if r.service != expected.service {
+				t.Error("militaryServiceRules service, testIndex: ", testIndex, i, r.service)
+			}
+			if r.minAge != expected.minAge || r.maxAge != expected.maxAge {
+				t.Error("militaryServiceRules age, testIndex: ", testIndex, i, r.minAge, r.maxAge)
+			}
+			if strings.Join(r.sexes, ",") != expected.sexes {
+				t.Error("militaryServiceRules sexes, testIndex: ", testIndex, i, r.sexes)
+			}
+			if r.obligationMonths != expected.obligationMonths {
+				t.Error("militaryServiceRules obligation, testIndex: ", testIndex, i, r.obligationMonths)
+			}
+			if r.conscriptionAbolished != expected.abolished {
+				t.Error("militaryServiceRules abolished, testIndex: ", testIndex, i, r.conscriptionAbolished)
+			}
+		}
+	}
+}
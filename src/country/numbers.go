@@ -0,0 +1,176 @@
+package country
+
+import (
+	"orderedmap"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// eg 5-10, 5% - 10%, $1 to $2
+var numericRangeRe = regexp.MustCompile(`^(\$?-?[0-9][0-9,]*\.?[0-9]*)\s*%?\s*(?:-|–|to)\s*(\$?-?[0-9][0-9,]*\.?[0-9]*)`)
+
+// Prefixes are checked in order so longer prefixes must come first.
+var comparatorPrefixes = []struct {
+	prefix     string
+	comparator string
+}{
+	{"less than ", "<"},
+	{"more than ", ">"},
+	{"approximately ", "~"},
+	{"about ", "~"},
+	{"<", "<"},
+	{">", ">"},
+	{"~", "~"},
+}
+
+// numericValue keeps the parts of a number which a single float64 loses,
+// eg "<1%" has comparator "<" and "5-10%" has an upper bound of 10.
+type numericValue struct {
+	lowerBound    float64
+	upperBound    float64
+	hasUpperBound bool
+	comparator    string
+	isEstimate    bool
+}
+
+func isEstimateStr(s string) bool {
+	return strings.Index(s, "est.") > -1
+}
+
+// Splits a number string into the lower bound, the upper bound of any range,
+// the comparator and whether it is an estimate.
+// eg "<$5-10 million est." is "$5 million est.", "$10 million est.", "<", true
+// The units and magnitude following the number are kept on both bounds,
+// so 1-2 million is 1 million to 2 million.
+func splitNumericQualifiers(s string) (string, string, string, bool) {
+	s = strings.TrimSpace(s)
+	isEstimate := isEstimateStr(s)
+	comparator := ""
+	for _, c := range comparatorPrefixes {
+		if startsWith(s, c.prefix) {
+			comparator = c.comparator
+			s = strings.TrimSpace(s[len(c.prefix):len(s)])
+			break
+		}
+	}
+	upper := ""
+	m := numericRangeRe.FindStringSubmatch(s)
+	if m != nil {
+		rest := s[len(m[0]):len(s)]
+		upper = m[2] + rest
+		s = m[1] + rest
+	}
+	return s, upper, comparator, isEstimate
+}
+
+func stringToNumericValue(s string) (numericValue, error) {
+	n := numericValue{}
+	sTrimmed := strings.TrimSpace(s)
+	if sTrimmed == "NA" {
+		return n, StringIsNaErr
+	}
+	// negligible is treated as approximately zero, see Antigua and Barbuda
+	if startsWith(sTrimmed, "NEGL") {
+		n.comparator = "~"
+		n.isEstimate = isEstimateStr(sTrimmed)
+		return n, nil
+	}
+	lower, upper, comparator, isEstimate := splitNumericQualifiers(sTrimmed)
+	n.comparator = comparator
+	n.isEstimate = isEstimate
+	lowerValue, err := stringToPlainNumber(lower)
+	if err != nil {
+		return n, err
+	}
+	n.lowerBound = lowerValue
+	if upper != "" {
+		upperValue, err := stringToPlainNumber(upper)
+		if err == nil {
+			n.upperBound = upperValue
+			n.hasUpperBound = true
+		}
+	}
+	return n, nil
+}
+
+// Returns the multiplier for any magnitude word in s, eg million is 1e6
+func numberMagnitude(s string) float64 {
+	magnitude := 1.0
+	for _, bit := range strings.Fields(s) {
+		if bit == "thousand" {
+			magnitude = 1e3
+		} else if bit == "million" {
+			magnitude = 1e6
+		} else if bit == "billion" {
+			magnitude = 1e9
+		} else if bit == "trillion" {
+			magnitude = 1e12
+		}
+	}
+	return magnitude
+}
+
+// Converts a number without any range or comparator, eg "$1.2 million"
+func stringToPlainNumber(s string) (float64, error) {
+	clean := strings.TrimSpace(s)
+	// anything after a percent sign or a dash is a note
+	bits := strings.Split(clean, "%")
+	if len(bits) > 1 {
+		clean = bits[0]
+	}
+	if strings.Index(clean, "-") > 0 {
+		bits = strings.Split(clean, "-")
+		clean = bits[0]
+	}
+	clean = strings.Replace(clean, "$", "", -1)
+	clean = strings.TrimSpace(clean)
+	var value float64
+	foundValue := false
+	for _, bit := range strings.Fields(clean) {
+		noCommas := strings.Replace(bit, ",", "", -1)
+		possibleValue, err := strconv.ParseFloat(noCommas, 64)
+		if err == nil {
+			value = possibleValue
+			foundValue = true
+			break
+		}
+	}
+	if !foundValue {
+		return value, StringToNumberWithUnitsErr
+	}
+	return value * numberMagnitude(clean), nil
+}
+
+// Adds the range, comparator and estimate flag to o where they are present.
+// Plain numbers are left unchanged.
+func setNumericQualifiers(o *orderedmap.OrderedMap, n numericValue) {
+	if n.hasUpperBound {
+		o.Set("lower_bound", n.lowerBound)
+		o.Set("upper_bound", n.upperBound)
+	}
+	if n.comparator != "" {
+		o.Set("comparator", n.comparator)
+	}
+	if n.isEstimate {
+		o.Set("is_estimate", true)
+	}
+}
+
+// Adds the range, comparator and estimate flag of the number at key to
+// qualifiers.<key> in o, for maps holding several numbers, eg total, male and
+// female. Plain numbers are left unchanged.
+func setNumericQualifiersForKey(o *orderedmap.OrderedMap, key string, n numericValue) {
+	q := orderedmap.New()
+	setNumericQualifiers(q, n)
+	if len(q.Keys()) == 0 {
+		return
+	}
+	qualifiers, exists := o.Get("qualifiers")
+	qualifiersMap, isMap := qualifiers.(*orderedmap.OrderedMap)
+	if !exists || !isMap {
+		qualifiersMap = orderedmap.New()
+		o.Set("qualifiers", qualifiersMap)
+	}
+	qualifiersMap.Set(key, q)
+}
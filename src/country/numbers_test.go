@@ -0,0 +1,306 @@
+package country
+
+import (
+	"orderedmap"
+	"testing"
+)
+
+type NumericValueCase struct {
+	s                     string
+	expectedLowerBound    float64
+	expectedUpperBound    float64
+	expectedHasUpperBound bool
+	expectedComparator    string
+	expectedIsEstimate    bool
+	expectedError         error
+}
+
+var numericValueCases = []NumericValueCase{
+	// plain number
+	NumericValueCase{
+		s:                  "1,234",
+		expectedLowerBound: 1234,
+	},
+	// range with percent
+	NumericValueCase{
+		s:                     "5-10%",
+		expectedLowerBound:    5,
+		expectedUpperBound:    10,
+		expectedHasUpperBound: true,
+	},
+	// range with percent on both bounds and spaces
+	NumericValueCase{
+		s:                     "84.7% - 89.7%",
+		expectedLowerBound:    84.7,
+		expectedUpperBound:    89.7,
+		expectedHasUpperBound: true,
+	},
+	// range with magnitude on the upper bound only
+	NumericValueCase{
+		s:                     "$1-2 million",
+		expectedLowerBound:    1e6,
+		expectedUpperBound:    2e6,
+		expectedHasUpperBound: true,
+	},
+	// less than
+	NumericValueCase{
+		s:                  "<1%",
+		expectedLowerBound: 1,
+		expectedComparator: "<",
+	},
+	// more than in words
+	NumericValueCase{
+		s:                  "more than 300",
+		expectedLowerBound: 300,
+		expectedComparator: ">",
+	},
+	// approximately
+	NumericValueCase{
+		s:                  "~2.5 million",
+		expectedLowerBound: 2.5e6,
+		expectedComparator: "~",
+	},
+	// estimate
+	NumericValueCase{
+		s:                  "$4.2 billion est.",
+		expectedLowerBound: 4.2e9,
+		expectedIsEstimate: true,
+	},
+	// negative numbers are not ranges
+	NumericValueCase{
+		s:                  "-$1.5 billion",
+		expectedLowerBound: -1.5e9,
+	},
+	// negligible
+	NumericValueCase{
+		s:                  "NEGL",
+		expectedLowerBound: 0,
+		expectedComparator: "~",
+	},
+	// not available
+	NumericValueCase{
+		s:             "NA",
+		expectedError: StringIsNaErr,
+	},
+	// no number
+	NumericValueCase{
+		s:             "none",
+		expectedError: StringToNumberWithUnitsErr,
+	},
+}
+
+func TestStringToNumericValue(t *testing.T) {
+	for testIndex, c := range numericValueCases {
+		n, err := stringToNumericValue(c.s)
+		if err != c.expectedError {
+			t.Error("stringToNumericValue error, testIndex: ", testIndex, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if n.lowerBound != c.expectedLowerBound {
+			t.Error("stringToNumericValue lowerBound, testIndex: ", testIndex, n.lowerBound)
+		}
+		if n.hasUpperBound != c.expectedHasUpperBound || n.upperBound != c.expectedUpperBound {
+			t.Error("stringToNumericValue upperBound, testIndex: ", testIndex, n.upperBound)
+		}
+		if n.comparator != c.expectedComparator {
+			t.Error("stringToNumericValue comparator, testIndex: ", testIndex, n.comparator)
+		}
+		if n.isEstimate != c.expectedIsEstimate {
+			t.Error("stringToNumericValue isEstimate, testIndex: ", testIndex, n.isEstimate)
+		}
+	}
+}
+
+func TestNumericQualifiersInMaps(t *testing.T) {
+	// ranges keep the lower bound as the value
+	o, err := stringToNumberWithUnits("5-10 sq km")
+	if err != nil {
+		t.Error("numeric qualifiers range error", err)
+	}
+	v, _ := o.Get("value")
+	if v.(float64) != 5 {
+		t.Error("numeric qualifiers range value", v)
+	}
+	u, _ := o.Get("upper_bound")
+	if u != 10.0 {
+		t.Error("numeric qualifiers range upper_bound", u)
+	}
+	units, _ := o.Get("units")
+	if units != "sq km" {
+		t.Error("numeric qualifiers range units", units)
+	}
+	// comparators on percentages
+	o, err = stringToPercentage("<1% (2017 est.)")
+	if err != nil {
+		t.Error("numeric qualifiers percentage error", err)
+	}
+	c, _ := o.Get("comparator")
+	if c != "<" {
+		t.Error("numeric qualifiers percentage comparator", c)
+	}
+	e, _ := o.Get("is_estimate")
+	if e != true {
+		t.Error("numeric qualifiers percentage is_estimate", e)
+	}
+	// plain numbers have no qualifiers
+	o, _ = stringToNumberWithUnits("1,234 km")
+	if len(o.Keys()) != 4 {
+		t.Error("numeric qualifiers plain number keys", o.Keys())
+	}
+}
+
+// NumericQualifiersCase gets the map holding one number from the output of a
+// converter, eg the first item of a percentage list
+type NumericQualifiersCase struct {
+	name               string
+	number             func() (*orderedmap.OrderedMap, error)
+	valueKey           string
+	expectedValue      float64
+	expectedComparator string
+	expectedUpperBound interface{}
+}
+
+func firstItem(o *orderedmap.OrderedMap, err error, key string, i int) (*orderedmap.OrderedMap, error) {
+	if err != nil {
+		return o, err
+	}
+	list, _ := o.Get(key)
+	items, _ := list.([]*orderedmap.OrderedMap)
+	if len(items) <= i {
+		return o, NoValueErr
+	}
+	return items[i], nil
+}
+
+func qualifiersForKey(o *orderedmap.OrderedMap, err error, key string) (*orderedmap.OrderedMap, error) {
+	if err != nil {
+		return o, err
+	}
+	q, _ := o.Get("qualifiers")
+	qualifiers, isMap := q.(*orderedmap.OrderedMap)
+	if !isMap {
+		return o, NoValueErr
+	}
+	v, _ := o.Get(key)
+	number, _ := qualifiers.Get(key)
+	m, isMap := number.(*orderedmap.OrderedMap)
+	if !isMap {
+		return o, NoValueErr
+	}
+	m.Set("value", v)
+	return m, nil
+}
+
+var numericQualifiersCases = []NumericQualifiersCase{
+	NumericQualifiersCase{"percentage list <", func() (*orderedmap.OrderedMap, error) {
+		o, err := stringToPercentageList("Albanian <1%, other 5-10%", "ethnicity")
+		return firstItem(o, err, "ethnicity", 0)
+	}, "percent", 1, "<", nil},
+	NumericQualifiersCase{"percentage list range", func() (*orderedmap.OrderedMap, error) {
+		o, err := stringToPercentageList("Albanian <1%, other 5-10%", "ethnicity")
+		return firstItem(o, err, "ethnicity", 1)
+	}, "percent", 5, "", 10.0},
+	NumericQualifiersCase{"percentage list >", func() (*orderedmap.OrderedMap, error) {
+		o, err := stringToPercentageList("Muslim >90%, other 2%", "religion")
+		return firstItem(o, err, "religion", 0)
+	}, "percent", 90, ">", nil},
+	NumericQualifiersCase{"map of numbers <", func() (*orderedmap.OrderedMap, error) {
+		o, err := stringToMapOfNumbers("total: <5\nmale: 5-10\nfemale: >3")
+		return qualifiersForKey(o, err, "total")
+	}, "value", 5, "<", nil},
+	NumericQualifiersCase{"map of numbers range", func() (*orderedmap.OrderedMap, error) {
+		o, err := stringToMapOfNumbers("total: <5\nmale: 5-10\nfemale: >3")
+		return qualifiersForKey(o, err, "male")
+	}, "value", 5, "", 10.0},
+	NumericQualifiersCase{"map of numbers >", func() (*orderedmap.OrderedMap, error) {
+		o, err := stringToMapOfNumbers("total: <5\nmale: 5-10\nfemale: >3")
+		return qualifiersForKey(o, err, "female")
+	}, "value", 3, ">", nil},
+	NumericQualifiersCase{"partners <", func() (*orderedmap.OrderedMap, error) {
+		o, err := stringToImportExportPartnerList("China <5%, US 10-12%, Japan >8% (2016)")
+		return firstItem(o, err, "by_country", 0)
+	}, "percent", 5, "<", nil},
+	NumericQualifiersCase{"partners range", func() (*orderedmap.OrderedMap, error) {
+		o, err := stringToImportExportPartnerList("China <5%, US 10-12%, Japan >8% (2016)")
+		return firstItem(o, err, "by_country", 1)
+	}, "percent", 10, "", 12.0},
+	NumericQualifiersCase{"partners >", func() (*orderedmap.OrderedMap, error) {
+		o, err := stringToImportExportPartnerList("China <5%, US 10-12%, Japan >8% (2016)")
+		return firstItem(o, err, "by_country", 2)
+	}, "percent", 8, ">", nil},
+	NumericQualifiersCase{"improved <", func() (*orderedmap.OrderedMap, error) {
+		o, err := stringToImprovedUnimprovedList("improved:\nurban: >99% of population\nrural: <50% of population\ntotal: 70-80% of population")
+		improved, _ := o.Get("improved")
+		rural, _ := improved.(*orderedmap.OrderedMap).Get("rural")
+		return rural.(*orderedmap.OrderedMap), err
+	}, "value", 50, "<", nil},
+	NumericQualifiersCase{"improved >", func() (*orderedmap.OrderedMap, error) {
+		o, err := stringToImprovedUnimprovedList("improved:\nurban: >99% of population\nrural: <50% of population\ntotal: 70-80% of population")
+		improved, _ := o.Get("improved")
+		urban, _ := improved.(*orderedmap.OrderedMap).Get("urban")
+		return urban.(*orderedmap.OrderedMap), err
+	}, "value", 99, ">", nil},
+	NumericQualifiersCase{"improved range", func() (*orderedmap.OrderedMap, error) {
+		o, err := stringToImprovedUnimprovedList("improved:\nurban: >99% of population\nrural: <50% of population\ntotal: 70-80% of population")
+		improved, _ := o.Get("improved")
+		total, _ := improved.(*orderedmap.OrderedMap).Get("total")
+		return total.(*orderedmap.OrderedMap), err
+	}, "value", 70, "", 80.0},
+	NumericQualifiersCase{"list of counts", func() (*orderedmap.OrderedMap, error) {
+		l, err := stringToListOfCounts("bulk carrier <8, cargo 7-9, tanker >4", "type")
+		if err != nil || len(l) != 3 {
+			return nil, NoValueErr
+		}
+		return l[0], nil
+	}, "count", 8, "<", nil},
+	NumericQualifiersCase{"list of counts range", func() (*orderedmap.OrderedMap, error) {
+		l, err := stringToListOfCounts("bulk carrier <8, cargo 7-9, tanker >4", "type")
+		if err != nil || len(l) != 3 {
+			return nil, NoValueErr
+		}
+		return l[1], nil
+	}, "count", 7, "", 9.0},
+	NumericQualifiersCase{"list of counts >", func() (*orderedmap.OrderedMap, error) {
+		l, err := stringToListOfCounts("bulk carrier <8, cargo 7-9, tanker >4", "type")
+		if err != nil || len(l) != 3 {
+			return nil, NoValueErr
+		}
+		return l[2], nil
+	}, "count", 4, ">", nil},
+	NumericQualifiersCase{"manpower >", func() (*orderedmap.OrderedMap, error) {
+		o, err := stringToManpower("males age 16-49: >1,000\nfemales age 16-49: 900-1,100 (2010 est.)")
+		return firstItem(o, err, "by_sex", 0)
+	}, "count", 1000, ">", nil},
+	NumericQualifiersCase{"manpower range", func() (*orderedmap.OrderedMap, error) {
+		o, err := stringToManpower("males age 16-49: >1,000\nfemales age 16-49: 900-1,100 (2010 est.)")
+		return firstItem(o, err, "by_sex", 1)
+	}, "count", 900, "", 1100.0},
+}
+
+func TestNumericQualifiersInConverters(t *testing.T) {
+	for _, c := range numericQualifiersCases {
+		o, err := c.number()
+		if err != nil || o == nil {
+			t.Error("numeric qualifiers error:", c.name, err)
+			continue
+		}
+		v, _ := o.Get(c.valueKey)
+		if v != c.expectedValue {
+			t.Error("numeric qualifiers value:", c.name, v)
+		}
+		comparator, _ := o.Get("comparator")
+		if c.expectedComparator != "" && comparator != c.expectedComparator {
+			t.Error("numeric qualifiers comparator:", c.name, comparator)
+		}
+		upper, hasUpper := o.Get("upper_bound")
+		if c.expectedUpperBound != nil && upper != c.expectedUpperBound {
+			t.Error("numeric qualifiers upper_bound:", c.name, upper)
+		}
+		if c.expectedUpperBound == nil && hasUpper {
+			t.Error("numeric qualifiers unexpected upper_bound:", c.name, upper)
+		}
+	}
+}
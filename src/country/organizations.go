@@ -0,0 +1,214 @@
+package country
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Full names of the international organizations listed in Appendix B of the
+// factbook, by the abbreviation used in the
+// international organization participation field.
+var organizationNames = map[string]string{
+	"ACP":                   "Group of African, Caribbean, and Pacific States",
+	"ADB":                   "Asian Development Bank",
+	"AfDB":                  "African Development Bank Group",
+	"AFESD":                 "Arab Fund for Economic and Social Development",
+	"AMF":                   "Arab Monetary Fund",
+	"AMU":                   "Arab Maghreb Union",
+	"ANZUS":                 "Australia-New Zealand-United States Security Treaty",
+	"APEC":                  "Asia-Pacific Economic Cooperation",
+	"ARF":                   "ASEAN Regional Forum",
+	"ASEAN":                 "Association of Southeast Asian Nations",
+	"AU":                    "African Union",
+	"Australia Group":       "Australia Group",
+	"BIS":                   "Bank for International Settlements",
+	"BSEC":                  "Organization of the Black Sea Economic Cooperation",
+	"C":                     "Commonwealth",
+	"CABEI":                 "Central American Bank for Economic Integration",
+	"CAEU":                  "Council of Arab Economic Unity",
+	"CAN":                   "Andean Community",
+	"Caricom":               "Caribbean Community and Common Market",
+	"CBSS":                  "Council of the Baltic Sea States",
+	"CD":                    "Community of Democracies",
+	"CDB":                   "Caribbean Development Bank",
+	"CE":                    "Council of Europe",
+	"CEI":                   "Central European Initiative",
+	"CELAC":                 "Community of Latin American and Caribbean States",
+	"CEMAC":                 "Economic and Monetary Community of Central Africa",
+	"CERN":                  "European Organization for Nuclear Research",
+	"CICA":                  "Conference on Interaction and Confidence-Building Measures in Asia",
+	"CIS":                   "Commonwealth of Independent States",
+	"COMESA":                "Common Market for Eastern and Southern Africa",
+	"CPLP":                  "Community of Portuguese Language Countries",
+	"CSTO":                  "Collective Security Treaty Organization",
+	"EAC":                   "East African Community",
+	"EAEC":                  "Eurasian Economic Community",
+	"EAEU":                  "Eurasian Economic Union",
+	"EAPC":                  "Euro-Atlantic Partnership Council",
+	"EAS":                   "East Asia Summit",
+	"EBRD":                  "European Bank for Reconstruction and Development",
+	"ECB":                   "European Central Bank",
+	"ECCAS":                 "Economic Community of Central African States",
+	"ECO":                   "Economic Cooperation Organization",
+	"ECOWAS":                "Economic Community of West African States",
+	"EEU":                   "Eurasian Economic Union",
+	"EFTA":                  "European Free Trade Association",
+	"EIB":                   "European Investment Bank",
+	"EITI":                  "Extractive Industries Transparency Initiative",
+	"EMU":                   "Economic and Monetary Union",
+	"ESA":                   "European Space Agency",
+	"EU":                    "European Union",
+	"FAO":                   "Food and Agriculture Organization",
+	"FATF":                  "Financial Action Task Force",
+	"FZ":                    "Franc Zone",
+	"G-15":                  "Group of 15",
+	"G-20":                  "Group of 20",
+	"G-24":                  "Group of 24",
+	"G-3":                   "Group of 3",
+	"G-5":                   "Group of 5",
+	"G-7":                   "Group of 7",
+	"G-8":                   "Group of 8",
+	"G-9":                   "Group of 9",
+	"G-10":                  "Group of 10",
+	"G-11":                  "Group of 11",
+	"G-77":                  "Group of 77",
+	"GCC":                   "Gulf Cooperation Council",
+	"IADB":                  "Inter-American Development Bank",
+	"IAEA":                  "International Atomic Energy Agency",
+	"IBRD":                  "International Bank for Reconstruction and Development",
+	"ICAO":                  "International Civil Aviation Organization",
+	"ICC":                   "International Chamber of Commerce",
+	"ICCt":                  "International Criminal Court",
+	"ICRM":                  "International Red Cross and Red Crescent Movement",
+	"IDA":                   "International Development Association",
+	"IDB":                   "Islamic Development Bank",
+	"IEA":                   "International Energy Agency",
+	"IFAD":                  "International Fund for Agricultural Development",
+	"IFC":                   "International Finance Corporation",
+	"IFRCS":                 "International Federation of Red Cross and Red Crescent Societies",
+	"IGAD":                  "Intergovernmental Authority on Development",
+	"IHO":                   "International Hydrographic Organization",
+	"ILO":                   "International Labor Organization",
+	"IMF":                   "International Monetary Fund",
+	"IMO":                   "International Maritime Organization",
+	"IMSO":                  "International Mobile Satellite Organization",
+	"Interpol":              "International Criminal Police Organization",
+	"IOC":                   "International Olympic Committee",
+	"IOM":                   "International Organization for Migration",
+	"IPU":                   "Inter-Parliamentary Union",
+	"ISO":                   "International Organization for Standardization",
+	"ITSO":                  "International Telecommunications Satellite Organization",
+	"ITU":                   "International Telecommunication Union",
+	"ITUC":                  "International Trade Union Confederation",
+	"LAES":                  "Latin American Economic System",
+	"LAIA":                  "Latin American Integration Association",
+	"LAS":                   "League of Arab States",
+	"Mercosur":              "Southern Cone Common Market",
+	"MIGA":                  "Multilateral Investment Guarantee Agency",
+	"MINURSO":               "United Nations Mission for the Referendum in Western Sahara",
+	"MINUSCA":               "United Nations Multidimensional Integrated Stabilization Mission in the Central African Republic",
+	"MINUSMA":               "United Nations Multidimensional Integrated Stabilization Mission in Mali",
+	"MINUSTAH":              "United Nations Stabilization Mission in Haiti",
+	"MONUSCO":               "United Nations Organization Stabilization Mission in the Democratic Republic of the Congo",
+	"NAM":                   "Nonaligned Movement",
+	"NATO":                  "North Atlantic Treaty Organization",
+	"NEA":                   "Nuclear Energy Agency",
+	"NIB":                   "Nordic Investment Bank",
+	"NSG":                   "Nuclear Suppliers Group",
+	"OAPEC":                 "Organization of Arab Petroleum Exporting Countries",
+	"OAS":                   "Organization of American States",
+	"OECD":                  "Organization for Economic Cooperation and Development",
+	"OECS":                  "Organization of Eastern Caribbean States",
+	"OIC":                   "Organization of Islamic Cooperation",
+	"OIF":                   "International Organization of the French-speaking World",
+	"OPANAL":                "Agency for the Prohibition of Nuclear Weapons in Latin America and the Caribbean",
+	"OPCW":                  "Organization for the Prohibition of Chemical Weapons",
+	"OPEC":                  "Organization of Petroleum Exporting Countries",
+	"OSCE":                  "Organization for Security and Cooperation in Europe",
+	"Pacific Alliance":      "Pacific Alliance",
+	"Paris Club":            "Paris Club",
+	"PCA":                   "Permanent Court of Arbitration",
+	"PFP":                   "Partnership for Peace",
+	"PIF":                   "Pacific Islands Forum",
+	"SAARC":                 "South Asian Association for Regional Cooperation",
+	"SACEP":                 "South Asia Co-operative Environment Programme",
+	"SACU":                  "Southern African Customs Union",
+	"SADC":                  "Southern African Development Community",
+	"SCO":                   "Shanghai Cooperation Organization",
+	"SELEC":                 "Southeast European Law Enforcement Center",
+	"SICA":                  "Central American Integration System",
+	"SPC":                   "Secretariat of the Pacific Community",
+	"UN":                    "United Nations",
+	"UN Security Council":   "United Nations Security Council",
+	"UNASUR":                "Union of South American Nations",
+	"UNCTAD":                "United Nations Conference on Trade and Development",
+	"UNDOF":                 "United Nations Disengagement Observer Force",
+	"UNESCO":                "United Nations Educational, Scientific, and Cultural Organization",
+	"UNFICYP":               "United Nations Peacekeeping Force in Cyprus",
+	"UNHCR":                 "United Nations Office of the High Commissioner for Refugees",
+	"UNHRC":                 "United Nations Human Rights Council",
+	"UNIDO":                 "United Nations Industrial Development Organization",
+	"UNIFIL":                "United Nations Interim Force in Lebanon",
+	"UNISFA":                "United Nations Interim Security Force for Abyei",
+	"UNMIK":                 "United Nations Interim Administration Mission in Kosovo",
+	"UNMIL":                 "United Nations Mission in Liberia",
+	"UNMISS":                "United Nations Mission in the Republic of South Sudan",
+	"UNMOGIP":               "United Nations Military Observer Group in India and Pakistan",
+	"UNOCI":                 "United Nations Operation in Cote d'Ivoire",
+	"UNSC":                  "United Nations Security Council",
+	"UNTSO":                 "United Nations Truce Supervision Organization",
+	"UNWTO":                 "World Tourism Organization",
+	"UPU":                   "Universal Postal Union",
+	"WADB":                  "West African Development Bank",
+	"WAEMU":                 "West African Economic and Monetary Union",
+	"Wassenaar Arrangement": "Wassenaar Arrangement",
+	"WCO":                   "World Customs Organization",
+	"WFTU":                  "World Federation of Trade Unions",
+	"WHO":                   "World Health Organization",
+	"WIPO":                  "World Intellectual Property Organization",
+	"WMO":                   "World Meteorological Organization",
+	"WTO":                   "World Trade Organization",
+	"Zangger Committee":     "Zangger Committee",
+}
+
+// Membership statuses in the order they are checked, eg
+// "associate member" is associate rather than member
+var membershipStatuses = []struct {
+	status string
+	re     *regexp.Regexp
+}{
+	{"observer", regexp.MustCompile(`(?i)\bobserver`)},
+	{"candidate", regexp.MustCompile(`(?i)\bcandidate`)},
+	{"associate", regexp.MustCompile(`(?i)\bassociate`)},
+	{"partner", regexp.MustCompile(`(?i)\bpartner`)},
+}
+
+// Returns the full name for an organization abbreviation from Appendix B,
+// eg UNESCO
+func OrganizationName(abbreviation string) (string, bool) {
+	name, exists := organizationNames[strings.TrimSpace(abbreviation)]
+	return name, exists
+}
+
+// Returns the membership status from the note for an organization, one of
+// member, observer, candidate, associate or partner. Notes without a status,
+// eg NGOs, are members.
+func MembershipStatus(note string) string {
+	for _, s := range membershipStatuses {
+		if s.re.MatchString(note) {
+			return s.status
+		}
+	}
+	return "member"
+}
+
+// Returns true if the note only contains the status, eg "(observer)" or
+// "(candidate country)", so it does not need to be kept as a note
+func noteIsOnlyStatus(note string) bool {
+	note = strings.ToLower(strings.TrimSpace(note))
+	switch note {
+	case "observer", "candidate", "candidate country", "associate", "associate member", "partner", "dialogue partner", "partner country":
+		return true
+	}
+	return false
+}
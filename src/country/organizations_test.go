@@ -0,0 +1,57 @@
+package country
+
+import (
+	"orderedmap"
+	"testing"
+)
+
+type OrganizationCase struct {
+	organization string
+	name         string
+	status       string
+	note         string
+}
+
+func TestInternationalOrganizationParticipation(t *testing.T) {
+	s := "ACP, AfDB, EITI (candidate country), ITUC (NGOs), OAS (observer), PIF (partner), UNSC (permanent), XYZ"
+	expected := []OrganizationCase{
+		OrganizationCase{"ACP", "Group of African, Caribbean, and Pacific States", "member", ""},
+		OrganizationCase{"AfDB", "African Development Bank Group", "member", ""},
+		OrganizationCase{"EITI", "Extractive Industries Transparency Initiative", "candidate", ""},
+		OrganizationCase{"ITUC", "International Trade Union Confederation", "member", "NGOs"},
+		OrganizationCase{"OAS", "Organization of American States", "observer", ""},
+		OrganizationCase{"PIF", "Pacific Islands Forum", "partner", ""},
+		OrganizationCase{"UNSC", "United Nations Security Council", "member", "permanent"},
+		// unknown abbreviations have no name
+		OrganizationCase{"XYZ", "", "member", ""},
+	}
+	v, err := internationalOrganizationParticipation(s)
+	if err != nil {
+		t.Error("internationalOrganizationParticipation error", err)
+		return
+	}
+	groups := v.([]*orderedmap.OrderedMap)
+	if len(groups) != len(expected) {
+		t.Error("internationalOrganizationParticipation length", len(groups))
+		return
+	}
+	for testIndex, c := range expected {
+		g := groups[testIndex]
+		organization, _ := g.Get("organization")
+		if organization != c.organization {
+			t.Error("organization, testIndex: ", testIndex, organization)
+		}
+		name, exists := g.Get("name")
+		if (len(c.name) > 0 || exists) && name != c.name {
+			t.Error("organization name, testIndex: ", testIndex, name)
+		}
+		status, _ := g.Get("status")
+		if status != c.status {
+			t.Error("organization status, testIndex: ", testIndex, status)
+		}
+		note, exists := g.Get("note")
+		if (len(c.note) > 0 || exists) && note != c.note {
+			t.Error("organization note, testIndex: ", testIndex, note)
+		}
+	}
+}
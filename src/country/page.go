@@ -7,15 +7,18 @@ import (
 	"net/url"
 	"orderedmap"
 	"path"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
 )
 
-const VERSION = "0.0.4-beta"
+const VERSION = "0.0.28-beta"
 
 var NoValueErr = errors.New("No value")
 
+var nonLetterRe = regexp.MustCompile(`[^A-Za-z]+`)
+
 type Page struct {
 	filelocation string
 	dom          *goquery.Document
@@ -66,6 +69,20 @@ func (p *Page) parse() error {
 	metaData.Set("date", date)
 	metaData.Set("source", url)
 	metaData.Set("nearby_dates", yearlySummaryUrl)
+	codes, hasCodes := CodesForFilename(p.filelocation)
+	if hasCodes {
+		metaData.Set("codes", codes.toMap())
+	}
+	region, regionCode, regionSource, err := regionFromDom(p.dom)
+	if err != nil && hasCodes {
+		region, regionCode, err = regionForCodes(codes)
+		regionSource = "region_table"
+	}
+	if err == nil {
+		metaData.Set("region", region)
+		metaData.Set("region_code", regionCode)
+		metaData.Set("region_source", regionSource)
+	}
 	// get the page data
 	pageData := orderedmap.New()
 	// Set the name
@@ -87,6 +104,10 @@ func (p *Page) parse() error {
 	tryAddingData(pageData, "military_and_security", p.militaryAndSecurity)
 	tryAddingData(pageData, "terrorism", p.terrorism)
 	tryAddingData(pageData, "transnational_issues", p.transnationalIssues)
+	// calculated from the sections above
+	tryAddingData(pageData, "derived", func() (interface{}, error) {
+		return DerivedIndicators(pageData)
+	})
 	d := orderedmap.New()
 	d.Set("data", pageData)
 	d.Set("metadata", metaData)
@@ -179,6 +200,7 @@ func (p *Page) geography() (interface{}, error) {
 	p.tryAddingDataForSelector(geoData, "population_distribution", Selector{"2266", "geography-population-distribution"}, populationDistribution)
 	p.tryAddingDataForSelector(geoData, "natural_hazards", Selector{"2021", "geography-natural-hazards"}, naturalHazards)
 	tryAddingData(geoData, "environment", p.environment)
+	tryAddingData(geoData, "territories", p.territories)
 	p.tryAddingDataForSelector(geoData, "note", Selector{"2113", "geography-note"}, geographyNote)
 	if len(geoData.Keys()) == 0 {
 		return geoData, NoValueErr
@@ -241,10 +263,12 @@ func (p *Page) government() (interface{}, error) {
 	p.tryAddingDataForSelector(governmentData, "capital", Selector{"2057", "government-capital"}, capital)
 	p.tryAddingDataForSelector(governmentData, "member_states", Selector{"2191", "government-member-states"}, memberStates)
 	p.tryAddingDataForSelector(governmentData, "administrative_divisions", Selector{"2051", "government-administrative-divisions"}, administrativeDivisions)
+	tryAddingData(governmentData, "administrative_division_counts", p.administrativeDivisionCounts)
 	p.tryAddingDataForSelector(governmentData, "dependent_areas", Selector{"2068", "government-dependent-areas"}, dependentAreas)
 	p.tryAddingDataForSelector(governmentData, "independence", Selector{"2088", "government-independence"}, independence)
 	p.tryAddingDataForSelector(governmentData, "national_holidays", Selector{"2109", "government-national-holiday"}, nationalHoliday)
 	p.tryAddingDataForSelector(governmentData, "constitution", Selector{"2063", "government-constitution"}, constitution)
+	tryAddingData(governmentData, "constitutional_timeline", p.constitutionalTimeline)
 	p.tryAddingDataForSelector(governmentData, "legal_system", Selector{"2100", "government-legal-system"}, legalSystem)
 	p.tryAddingDataForSelector(governmentData, "international_law_organization_participation", Selector{"2220", "government-international-law-organization-participation"}, internationalLaw)
 	p.tryAddingDataForSelector(governmentData, "citizenship", Selector{"2263", "government-citizenship"}, citizenship)
@@ -302,6 +326,11 @@ func (p *Page) economy() (interface{}, error) {
 	tryAddingData(economyData, "stock_of_direct_foreign_investment", p.stockOfDirectForeignInvestment)
 	p.tryAddingDataForSelector(economyData, "exchange_rates", Selector{"2076", "economy-exchange-rates"}, exchangeRates)
 	//p.tryAddingDataForSelector(economyData, "economy_of_the_area_administered_by_turkish_cypriots", Selector{"2204", ""}, economyOfTurkishCypriots)
+	// derived from the fields above, so it comes last
+	fiscalData, err := fiscal(economyData)
+	if err == nil {
+		economyData.Set("fiscal", fiscalData)
+	}
 	if len(economyData.Keys()) == 0 {
 		return economyData, NoValueErr
 	}
@@ -401,23 +430,17 @@ func geographicOverview(value string) (interface{}, error) {
 }
 
 func geographyLocation(value string) (interface{}, error) {
-	// handle edge cases
-	// see france
-	value = convertToFranceValue(value)
+	value = pageTerritoryValue(value)
 	return value, nil
 }
 
 func geographicCoordinates(value string) (interface{}, error) {
-	// handle edge cases
-	// see france
-	value = convertToFranceValue(value)
+	value = pageTerritoryValue(value)
 	return stringToGPS(value)
 }
 
 func mapReferences(value string) (interface{}, error) {
-	// handle edge cases
-	// see france
-	value = convertToFranceValue(value)
+	value = pageTerritoryValue(value)
 	return value, nil
 }
 
@@ -433,44 +456,18 @@ func (p *Page) geographyArea() (interface{}, error) {
 	if err != nil {
 		return areas, err
 	}
-	// handle edge cases
-	// see france
-	if strings.Index(areas, "metropolitan France") > -1 {
-		m.Delete("note")
-		totalMap, ok := m.Get("total")
-		if ok {
-			totalStr, ok := totalMap.(*orderedmap.OrderedMap).Get("note")
-			if ok {
-				total, err := stringToNumberWithUnits(totalStr.(string))
-				if err == nil {
-					total.Delete("note")
-					m.Set("total", total)
-				}
-			}
-		}
-		landMap, ok := m.Get("land")
-		if ok {
-			landStr, ok := landMap.(*orderedmap.OrderedMap).Get("note")
-			if ok {
-				land, err := stringToNumberWithUnits(landStr.(string))
-				if err == nil {
-					land.Delete("note")
-					m.Set("land", land)
-				}
-			}
+	// pages with several territories have the values for all territories
+	// first, and the values for each territory under territories
+	values := splitMapByTerritory(areas)
+	if len(values) > 0 {
+		note, hasNote := m.Get("note")
+		m, err = stringToMapOfNumbersWithUnits(values[0].value)
+		if err != nil {
+			return areas, err
 		}
-		waterMap, ok := m.Get("water")
-		if ok {
-			waterStr, ok := waterMap.(*orderedmap.OrderedMap).Get("note")
-			if ok {
-				water, err := stringToNumberWithUnits(waterStr.(string))
-				if err == nil {
-					water.Delete("note")
-					m.Set("water", water)
-				}
-			}
+		if hasNote {
+			m.Set("note", note)
 		}
-		m.Set("note", "metropolitan France")
 	}
 	// comparative
 	comparative, err := textForSelector(p.dom, Selector{"2023", "geography-area-comparative"})
@@ -483,15 +480,7 @@ func (p *Page) geographyArea() (interface{}, error) {
 }
 
 func landBoundaries(value string) (interface{}, error) {
-	// fix edge cases
-	// see france
-	if strings.Index(value, "metropolitan France") > -1 {
-		value = strings.Replace(value, "metropolitan France - total:", "total:", -1)
-		otherCountriesStart := strings.Index(value, "French Guiana - total")
-		if otherCountriesStart > -1 {
-			value = value[0:otherCountriesStart]
-		}
-	}
+	value = pageTerritoryValue(value)
 	// might be a map
 	// or might be just a number
 	// so try map conversion first
@@ -541,8 +530,7 @@ func coastline(value string) (interface{}, error) {
 	if strings.Index(value, "Saint Helena:") > -1 {
 		return value, NoValueErr
 	}
-	// see france
-	value = convertToFranceValue(value)
+	value = pageTerritoryValue(value)
 	first, others := firstLine(value)
 	o, err := stringToNumberWithUnits(first)
 	if err != nil {
@@ -571,16 +559,12 @@ func maritimeClaims(value string) (interface{}, error) {
 }
 
 func climate(value string) (interface{}, error) {
-	// fix edge cases
-	// see france
-	value = convertToFranceValue(value)
+	value = pageTerritoryValue(value)
 	return value, nil
 }
 
 func terrain(value string) (interface{}, error) {
-	// fix edge cases
-	// see france
-	value = convertToFranceValue(value)
+	value = pageTerritoryValue(value)
 	return value, nil
 }
 
@@ -628,9 +612,7 @@ func elevation(value string) (interface{}, error) {
 
 func naturalResources(value string) (interface{}, error) {
 	o := orderedmap.New()
-	// fix edge cases
-	// see france
-	value = convertToFranceValue(value)
+	value = pageTerritoryValue(value)
 	// list is only first line
 	first, others := firstLine(value)
 	lc := listConditions{
@@ -743,9 +725,7 @@ func landUse(value string) (interface{}, error) {
 }
 
 func irrigatedLand(value string) (interface{}, error) {
-	// fix edge cases
-	// see france
-	value = convertToFranceValue(value)
+	value = pageTerritoryValue(value)
 	return stringToNumberWithUnitsAndDate(value)
 }
 
@@ -762,9 +742,7 @@ func populationDistribution(value string) (interface{}, error) {
 }
 
 func naturalHazards(value string) (interface{}, error) {
-	// fix edge cases
-	// see france
-	value = convertToFranceValue(value)
+	value = pageTerritoryValue(value)
 	// first line is a list
 	firstLine, otherLines := firstLine(value)
 	lc := listConditions{
@@ -855,7 +833,7 @@ func geographyNote(value string) (interface{}, error) {
 }
 
 func population(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "total")
+	return stringToNumberWithGlobalRankAndDate(value, "total", "")
 }
 
 func nationality(value string) (interface{}, error) {
@@ -863,26 +841,16 @@ func nationality(value string) (interface{}, error) {
 }
 
 func ethnicGroups(value string) (interface{}, error) {
+	value, _ = splitPageAndTerritories(value)
 	// See france
 	value = strings.Replace(value, "Celtic and Latin with Teutonic", "Celtic, Latin, Teutonic", -1)
 	value = strings.Replace(value, ", Basque minorities", ", Basque", -1)
-	o, err := stringToPercentageList(value, "ethnicity")
-	if err != nil {
-		return o, err
-	}
-	// See france
-	o.Delete("overseas_departments")
-	return o, nil
+	return stringToPercentageList(value, "ethnicity")
 }
 
 func languages(value string) (interface{}, error) {
-	o, err := stringToPercentageList(value, "language")
-	if err != nil {
-		return o, err
-	}
-	// See france
-	o.Delete("overseas_departments")
-	return o, nil
+	value, _ = splitPageAndTerritories(value)
+	return stringToPercentageList(value, "language")
 }
 
 func religions(value string) (interface{}, error) {
@@ -906,47 +874,39 @@ func demographicProfile(value string) (interface{}, error) {
 }
 
 func ageStructure(value string) (interface{}, error) {
-	value, date, hasDate := stringWithoutDate(value)
+	value, date, hasDate := stringWithoutDateDetail(value)
 	lines := strings.Split(value, "\n")
 	o := orderedmap.New()
+	brackets := []ageBracket{}
 	for _, line := range lines {
-		key := ""
-		if strings.Index(line, "0-14 years:") == 0 {
-			key = "0_to_14"
-		} else if strings.Index(line, "15-24 years:") == 0 {
-			key = "15_to_24"
-		} else if strings.Index(line, "25-54 years:") == 0 {
-			key = "25_to_54"
-		} else if strings.Index(line, "55-64 years:") == 0 {
-			key = "55_to_64"
-		} else if strings.Index(line, "65 years and over:") == 0 {
-			key = "65_and_over"
-		}
-		if key == "" {
-			continue
-		}
-		ages, err := stringToAgeStructureMap(line)
+		bracket, err := stringToAgeBracket(line)
 		if err != nil {
 			continue
 		}
+		ages := bracket.toMap()
 		if len(ages.Keys()) > 0 {
-			o.Set(key, ages)
+			o.Set(bracket.key(), ages)
+			brackets = append(brackets, bracket)
 		}
 	}
+	if len(brackets) == 0 {
+		return o, NoValueErr
+	}
+	o.Set("pyramid", agePyramid(brackets))
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
 
 func dependencyRatios(value string) (interface{}, error) {
-	value, date, hasDate := stringWithoutDate(value)
+	value, date, hasDate := stringWithoutDateDetail(value)
 	o, err := stringToPercentageMap(value, "ratios")
 	if err != nil {
 		return o, err
 	}
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
@@ -954,31 +914,31 @@ func dependencyRatios(value string) (interface{}, error) {
 func medianAge(value string) (interface{}, error) {
 	// See Micronesia
 	value = strings.Replace(value, "24total:", "total:", -1)
-	value, date, hasDate := stringWithoutDate(value)
+	value, date, hasDate := stringWithoutDateDetail(value)
 	o, err := stringToMapOfNumbersWithUnits(value)
 	if err != nil {
 		return o, err
 	}
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
 
 func populationGrowthRate(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "growth_rate")
+	return stringToNumberWithGlobalRankAndDate(value, "growth_rate", "%")
 }
 
 func birthRate(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "births_per_1000_population")
+	return stringToNumberWithGlobalRankAndDate(value, "births_per_1000_population", "births/1,000 population")
 }
 
 func deathRate(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "deaths_per_1000_population")
+	return stringToNumberWithGlobalRankAndDate(value, "deaths_per_1000_population", "deaths/1,000 population")
 }
 
 func netMigrationRate(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "migrants_per_1000_population")
+	return stringToNumberWithGlobalRankAndDate(value, "migrants_per_1000_population", "migrants/1,000 population")
 }
 
 func urbanization(value string) (interface{}, error) {
@@ -990,7 +950,7 @@ func urbanization(value string) (interface{}, error) {
 	for _, k := range o.Keys() {
 		vInterface, _ := o.Get(k)
 		v := vInterface.(string)
-		v, date, hasDate := stringWithoutDate(v)
+		v, date, hasDate := stringWithoutDateDetail(v)
 		vBits := strings.Split(v, "%")
 		if len(vBits) == 2 {
 			percent, err := strconv.ParseFloat(vBits[0], 64)
@@ -1001,7 +961,7 @@ func urbanization(value string) (interface{}, error) {
 			percentMap.Set("value", percent)
 			percentMap.Set("units", "%")
 			if hasDate {
-				percentMap.Set("date", date)
+				setDate(percentMap, date)
 			}
 			o.Set(k, percentMap)
 		} else if k == "ten_largest_urban_agglomerations" {
@@ -1019,20 +979,21 @@ func urbanization(value string) (interface{}, error) {
 					continue
 				}
 				city := parts[0]
-				population, err := stringToNumber(parts[1])
+				population, err := stringToNumericValue(parts[1])
 				if err != nil {
 					continue
 				}
 				c := orderedmap.New()
 				c.Set("city", city)
 				c.Set("country", country)
-				c.Set("population", population)
+				c.Set("population", population.lowerBound)
+				setNumericQualifiers(c, population)
 				cities = append(cities, c)
 			}
 			if len(cities) > 0 {
 				citiesMap.Set("by_population", cities)
 				if hasDate {
-					citiesMap.Set("date", date)
+					setDate(citiesMap, date)
 				}
 				o.Set(k, citiesMap)
 			}
@@ -1051,7 +1012,7 @@ func urbanization(value string) (interface{}, error) {
 
 func majorUrbanAreas(value string) (interface{}, error) {
 	// parse date
-	s, date, hasDate := stringWithoutDate(value)
+	s, date, hasDate := stringWithoutDateDetail(value)
 	// parse list of places and populations
 	o := orderedmap.New()
 	places := []*orderedmap.OrderedMap{}
@@ -1081,7 +1042,7 @@ func majorUrbanAreas(value string) (interface{}, error) {
 			continue
 		}
 		// get place population
-		population, err := stringToNumber(populationStr)
+		population, err := stringToNumericValue(populationStr)
 		hasPopulation := err == nil
 		// get capital / note
 		notes := []string{}
@@ -1097,7 +1058,8 @@ func majorUrbanAreas(value string) (interface{}, error) {
 		// store the parsed data
 		placeMap.Set("place", placeStr)
 		if hasPopulation {
-			placeMap.Set("population", population)
+			placeMap.Set("population", population.lowerBound)
+			setNumericQualifiers(placeMap, population)
 		}
 		if isCapital {
 			placeMap.Set("is_capital", isCapital)
@@ -1113,21 +1075,21 @@ func majorUrbanAreas(value string) (interface{}, error) {
 	o.Set("places", places)
 	// set date
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
 
 func sexRatio(value string) (interface{}, error) {
 	// parse date
-	s, date, hasDate := stringWithoutDate(value)
+	s, date, hasDate := stringWithoutDateDetail(value)
 	// parse ratios
 	ratios := orderedmap.New()
 	m, err := stringToMap(s)
 	if err != nil {
 		return m, err
 	}
-	var total float64
+	var total numericValue
 	hasTotal := false
 	keys := m.Keys()
 	units := "males/female"
@@ -1138,7 +1100,7 @@ func sexRatio(value string) (interface{}, error) {
 		if len(bits) == 0 {
 			continue
 		}
-		value, err := stringToNumber(bits[0])
+		value, err := stringToNumericValue(bits[0])
 		if err != nil {
 			continue
 		}
@@ -1151,8 +1113,9 @@ func sexRatio(value string) (interface{}, error) {
 			k = strings.Replace(k, "25_54", "25_to_54", -1)
 			k = strings.Replace(k, "55_64", "55_to_64", -1)
 			valueWithUnits := orderedmap.New()
-			valueWithUnits.Set("value", value)
+			valueWithUnits.Set("value", value.lowerBound)
 			valueWithUnits.Set("units", units)
+			setNumericQualifiers(valueWithUnits, value)
 			ratios.Set(k, valueWithUnits)
 		}
 	}
@@ -1164,36 +1127,38 @@ func sexRatio(value string) (interface{}, error) {
 	o.Set("by_age", ratios)
 	if hasTotal {
 		totalWithUnits := orderedmap.New()
-		totalWithUnits.Set("value", total)
+		totalWithUnits.Set("value", total.lowerBound)
 		totalWithUnits.Set("units", units)
+		setNumericQualifiers(totalWithUnits, total)
 		o.Set("total_population", totalWithUnits)
 	}
 	// set date
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
 
 func mothersMeanAgeAtFirstBirth(value string) (interface{}, error) {
 	// parse date
-	s, date, hasDate := stringWithoutDate(value)
+	s, date, hasDate := stringWithoutDateDetail(value)
 	// parse age
 	s = strings.TrimSpace(s)
-	age, err := stringToNumber(s)
+	age, err := stringToNumericValue(s)
 	if err != nil {
-		return age, err
+		return age.lowerBound, err
 	}
 	o := orderedmap.New()
-	o.Set("age", age)
+	o.Set("age", age.lowerBound)
+	setNumericQualifiers(o, age)
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
 
 func maternalMortalityRate(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "deaths_per_100k_live_births")
+	return stringToNumberWithGlobalRankAndDate(value, "deaths_per_100k_live_births", "deaths/100,000 live births")
 }
 
 func infantMortalityRate(value string) (interface{}, error) {
@@ -1206,7 +1171,7 @@ func lifeExpectancyAtBirth(value string) (interface{}, error) {
 }
 
 func totalFertilityRate(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "children_born_per_woman")
+	return stringToNumberWithGlobalRankAndDate(value, "children_born_per_woman", "children born/woman")
 }
 
 func contraceptivePrevalenceRate(value string) (interface{}, error) {
@@ -1215,17 +1180,17 @@ func contraceptivePrevalenceRate(value string) (interface{}, error) {
 
 func healthExpenditures(value string) (interface{}, error) {
 	value = strings.Replace(value, "% of GDP", " percent_of_gdp", -1)
-	return stringToNumberWithGlobalRankAndDate(value, "percent_of_gdp")
+	return stringToNumberWithGlobalRankAndDate(value, "percent_of_gdp", "%")
 }
 
 func physiciansDensity(value string) (interface{}, error) {
 	value = strings.Replace(value, "physicians/1,000 population", " physicians_per_1000_population", -1)
-	return stringToNumberWithGlobalRankAndDate(value, "physicians_per_1000_population")
+	return stringToNumberWithGlobalRankAndDate(value, "physicians_per_1000_population", "physicians/1,000 population")
 }
 
 func hospitalBedDensity(value string) (interface{}, error) {
 	value = strings.Replace(value, "beds/1,000 population", " beds_per_1000_population", -1)
-	return stringToNumberWithGlobalRankAndDate(value, "beds_per_1000_population")
+	return stringToNumberWithGlobalRankAndDate(value, "beds_per_1000_population", "beds/1,000 population")
 }
 
 func drinkingWaterSource(value string) (interface{}, error) {
@@ -1242,7 +1207,7 @@ func (p *Page) hivAids() (interface{}, error) {
 	// adult prevalence rate
 	rateStr, err := textForSelector(p.dom, Selector{"2155", "people-and-society-hiv-aids-adult-prevalence-rate"})
 	if err == nil {
-		rate, err := stringToNumberWithGlobalRankAndDate(rateStr, "percent_of_adults")
+		rate, err := stringToNumberWithGlobalRankAndDate(rateStr, "percent_of_adults", "%")
 		if err == nil {
 			o.Set("adult_prevalence_rate", rate)
 		}
@@ -1250,7 +1215,7 @@ func (p *Page) hivAids() (interface{}, error) {
 	// people living with hiv aids
 	livingStr, err := textForSelector(p.dom, Selector{"2156", "people-and-society-hiv-aids-people-living-with-hiv-aids"})
 	if err == nil {
-		living, err := stringToNumberWithGlobalRankAndDate(livingStr, "total")
+		living, err := stringToNumberWithGlobalRankAndDate(livingStr, "total", "")
 		if err == nil {
 			o.Set("people_living_with_hiv_aids", living)
 		}
@@ -1258,7 +1223,7 @@ func (p *Page) hivAids() (interface{}, error) {
 	// deaths
 	deathsStr, err := textForSelector(p.dom, Selector{"2157", "people-and-society-hiv-aids-deaths"})
 	if err == nil {
-		deaths, err := stringToNumberWithGlobalRankAndDate(deathsStr, "total")
+		deaths, err := stringToNumberWithGlobalRankAndDate(deathsStr, "total", "")
 		if err == nil {
 			o.Set("deaths", deaths)
 		}
@@ -1275,7 +1240,7 @@ func majorInfectiousDiseases(value string) (interface{}, error) {
 	value = strings.Replace(value, "bacterial and protozoal diarrhea", "bacterial diarrhea, protozoal diarrhea", -1)
 	value = strings.Replace(value, " disease:", " diseases:", -1)
 	value = strings.Replace(value, "hepatitis A and E", "hepatitis A, hepatitis E", -1)
-	value, date, hasDate := stringWithoutDate(value)
+	value, date, hasDate := stringWithoutDateDetail(value)
 	o, err := stringToMap(value)
 	if err != nil {
 		return o, err
@@ -1297,27 +1262,27 @@ func majorInfectiousDiseases(value string) (interface{}, error) {
 		}
 	}
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
 
 func obesityAdultPrevalenceRate(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "percent_of_adults")
+	return stringToNumberWithGlobalRankAndDate(value, "percent_of_adults", "%")
 }
 
 func childrenUnderFiveYearsUnderweight(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "percent_of_children_under_the_age_of_five")
+	return stringToNumberWithGlobalRankAndDate(value, "percent_of_children_under_the_age_of_five", "%")
 }
 
 func educationExpenditures(value string) (interface{}, error) {
 	value = strings.Replace(value, "% of GDP", " percent_of_gdp", -1)
-	return stringToNumberWithGlobalRankAndDate(value, "percent_of_gdp")
+	return stringToNumberWithGlobalRankAndDate(value, "percent_of_gdp", "%")
 }
 
 func literacy(value string) (interface{}, error) {
 	value = strings.Replace(value, "notes:", "note:", -1)
-	value, date, hasDate := stringWithoutDate(value)
+	value, date, hasDate := stringWithoutDateDetail(value)
 	o, err := stringToMap(value)
 	if err != nil {
 		return o, err
@@ -1337,7 +1302,7 @@ func literacy(value string) (interface{}, error) {
 		}
 	}
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
@@ -1347,7 +1312,7 @@ func schoolLifeExpectancy(value string) (interface{}, error) {
 }
 
 func childLabor(value string) (interface{}, error) {
-	value, date, hasDate := stringWithoutDate(value)
+	value, date, hasDate := stringWithoutDateDetail(value)
 	o, err := stringToMap(value)
 	if err != nil {
 		return o, err
@@ -1357,11 +1322,12 @@ func childLabor(value string) (interface{}, error) {
 		vInterface, _ := o.Get(key)
 		v := vInterface.(string)
 		if key == "total_number" {
-			num, err := stringToNumber(v)
+			num, err := stringToNumericValue(v)
 			if err != nil {
 				continue
 			}
-			o.Set(key, num)
+			o.Set(key, num.lowerBound)
+			setNumericQualifiersForKey(o, key, num)
 		}
 		if key == "percentage" {
 			num, err := stringToNumberWithUnits(v)
@@ -1372,7 +1338,7 @@ func childLabor(value string) (interface{}, error) {
 		}
 	}
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
@@ -1470,6 +1436,7 @@ func administrativeDivisions(value string) (interface{}, error) {
 		if len(names) == 0 {
 			return names, NoValueErr
 		}
+		setNormalisedDivisionTypes(names)
 		return names, nil
 	}
 	// Falkland Islands
@@ -1532,7 +1499,7 @@ func administrativeDivisions(value string) (interface{}, error) {
 		// get type name
 		typeNameIndex := strings.Count(name, "*")
 		typeName := ""
-		if len(typeNames) < typeNameIndex {
+		if typeNameIndex < len(typeNames) {
 			typeName = typeNames[typeNameIndex]
 		}
 		// set name
@@ -1545,6 +1512,7 @@ func administrativeDivisions(value string) (interface{}, error) {
 	if len(namesWithTypes) == 0 {
 		return namesWithTypes, NoValueErr
 	}
+	setNormalisedDivisionTypes(namesWithTypes)
 	return namesWithTypes, nil
 }
 
@@ -1601,88 +1569,7 @@ func independence(value string) (interface{}, error) {
 }
 
 func nationalHoliday(value string) (interface{}, error) {
-	// see Bahrain
-	value = strings.Replace(value, "; note - ", " ", -1)
-	// see Boznia and Hergezovia
-	if strings.Index(value, "Bosnia and Herzegovina") > -1 {
-		value = strings.Replace(value, "\n", "; ", -1)
-	}
-	// see China
-	if strings.Index(value, "China") > -1 {
-		before := ", the anniversary of the founding of the People's Republic of China"
-		after := " (the anniversary of the founding of the People's Republic of China)"
-		value = strings.Replace(value, before, after, -1)
-	}
-	// see Croatia
-	if strings.Index(value, "Croatian") > -1 {
-		value = strings.Replace(value, ") and", "); ", -1)
-		value = strings.Replace(value, "independence; following", "independence. Following", -1)
-	}
-	// See Curacao
-	value = strings.Replace(value, "27 April 1967", "27 April (1967)", -1)
-	// See Iraq
-	value = strings.Replace(value, "July 14", "14 July", -1)
-	// See Liechtenstein
-	if strings.Index(value, "Assumption Day") > -1 {
-		value = strings.Replace(value, ", 15 August, and", " and", -1)
-	}
-	// See Luxembourg
-	if strings.Index(value, "Grand Duke Henri") > -1 {
-		value = strings.Replace(value, ") ", "), ", -1)
-	}
-	// See Paraguay
-	value = strings.Replace(value, " 1811 ", " (1811) ", -1)
-	// See European Union
-	if strings.Index(value, "Europe Day") > -1 {
-		value = strings.Replace(value, "Day) ", "Day), ", -1)
-	}
-	// get holidays
-	bits := strings.Split(value, "; ")
-	holidays := []*orderedmap.OrderedMap{}
-	for _, bit := range bits {
-		h := orderedmap.New()
-		s, ps := removeParenthesis(bit)
-		sBits := strings.Split(s, ", ")
-		if len(sBits) < 2 {
-			continue
-		}
-		notes := []string{}
-		name := strings.TrimSpace(sBits[0])
-		dayStr := strings.TrimSpace(strings.Join(sBits[1:len(sBits)], ", "))
-		dayBits := strings.Split(dayStr, " ")
-		day := dayStr
-		if startsWithNumber(dayStr) {
-			if len(dayBits) > 1 {
-				day = strings.Join(dayBits[0:2], " ")
-			}
-			if len(dayBits) > 2 {
-				n := strings.Join(dayBits[2:len(dayBits)], " ")
-				notes = append(notes, strings.TrimSpace(n))
-			}
-		}
-		originalYear := ""
-		for _, p := range ps {
-			_, err := time.Parse("2006", p)
-			if err != nil {
-				notes = append(notes, p)
-			} else {
-				originalYear = p
-			}
-		}
-		h.Set("name", name)
-		h.Set("day", day)
-		if len(originalYear) > 0 {
-			h.Set("original_year", originalYear)
-		}
-		if len(notes) > 0 {
-			h.Set("note", strings.Join(notes, "; "))
-		}
-		holidays = append(holidays, h)
-	}
-	if len(holidays) == 0 {
-		return holidays, NoValueErr
-	}
-	return holidays, nil
+	return stringToNationalHolidays(value)
 }
 
 func constitution(value string) (interface{}, error) {
@@ -1714,42 +1601,16 @@ func internationalLaw(value string) (interface{}, error) {
 }
 
 func citizenship(value string) (interface{}, error) {
-	return stringToMap(value)
+	o, err := stringToMap(value)
+	if err != nil {
+		return o, err
+	}
+	typedCitizenship(o)
+	return o, nil
 }
 
 func suffrage(value string) (interface{}, error) {
-	o := orderedmap.New()
-	bits := strings.Split(value, " ")
-	if len(bits) > 0 {
-		age, err := stringToNumber(bits[0])
-		if err == nil {
-			o.Set("age", age)
-		}
-	}
-	if strings.Index(value, "universal and compulsory") > -1 {
-		o.Set("universal", true)
-		o.Set("compulsory", true)
-	} else if strings.Index(value, "universal") > -1 {
-		o.Set("universal", true)
-		o.Set("compulsory", false)
-	}
-	// note
-	// ie anything not of the common form "X years of age; universal [and compulsory]"
-	withoutAge := strings.Join(bits[1:len(bits)], " ")
-	withoutAge = strings.Replace(withoutAge, "universal and compulsory", "", -1)
-	withoutAge = strings.Replace(withoutAge, "universal", "", -1)
-	withoutAge = strings.Replace(withoutAge, ";", "", -1)
-	withoutAge = strings.Replace(withoutAge, ",", "", -1)
-	withoutAge = strings.Replace(withoutAge, "years of age", "", -1)
-	withoutAge = strings.TrimSpace(withoutAge)
-	if len(withoutAge) > 0 {
-		o.Set("note", value)
-	}
-	keys := o.Keys()
-	if len(keys) == 0 {
-		return o, NoValueErr
-	}
-	return o, nil
+	return stringToSuffrage(value)
 }
 
 func executiveBranch(value string) (interface{}, error) {
@@ -2070,9 +1931,21 @@ func internationalOrganizationParticipation(value string) (interface{}, error) {
 	for _, bit := range bits {
 		group := orderedmap.New()
 		name, notes := removeParenthesis(bit)
+		name = strings.TrimSpace(name)
 		group.Set("organization", name)
-		if len(notes) > 0 {
-			group.Set("note", strings.Join(notes, "; "))
+		fullName, exists := OrganizationName(name)
+		if exists {
+			group.Set("name", fullName)
+		}
+		group.Set("status", MembershipStatus(strings.Join(notes, "; ")))
+		otherNotes := []string{}
+		for _, note := range notes {
+			if !noteIsOnlyStatus(note) {
+				otherNotes = append(otherNotes, note)
+			}
+		}
+		if len(otherNotes) > 0 {
+			group.Set("note", strings.Join(otherNotes, "; "))
 		}
 		groups = append(groups, group)
 	}
@@ -2258,7 +2131,7 @@ func gdpPpp(value string) (interface{}, error) {
 }
 
 func gdpOfficialExchangeRate(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "USD")
+	return stringToNumberWithGlobalRankAndDate(value, "USD", "USD")
 }
 
 func gdpRealGrowthRate(value string) (interface{}, error) {
@@ -2465,7 +2338,7 @@ func industries(value string) (interface{}, error) {
 }
 
 func industrialProductionGrowthRate(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "annual_percentage_increase")
+	return stringToNumberWithGlobalRankAndDate(value, "annual_percentage_increase", "%")
 }
 
 func (p *Page) laborForce() (interface{}, error) {
@@ -2480,7 +2353,7 @@ func (p *Page) laborForce() (interface{}, error) {
 }
 
 func laborForceTotal(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "total_people")
+	return stringToNumberWithGlobalRankAndDate(value, "total_people", "people")
 }
 
 func laborForceByOccupation(value string) (interface{}, error) {
@@ -2526,19 +2399,27 @@ func investmentGrossFixed(value string) (interface{}, error) {
 }
 
 func budget(value string) (interface{}, error) {
-	value = strings.Replace(value, "$", "", -1)
-	value = strings.Replace(value, "illion", "illion USD", -1)
-	return stringToMapOfNumbersWithUnits(value)
+	lines := strings.Split(value, "\n")
+	for i, line := range lines {
+		bits := strings.SplitN(line, ":", 2)
+		if len(bits) < 2 || strings.TrimSpace(bits[0]) == "note" {
+			continue
+		}
+		v, code := budgetValueCurrency(bits[1])
+		v = strings.Replace(v, "illion", "illion "+code, 1)
+		lines[i] = bits[0] + ": " + v
+	}
+	return stringToMapOfNumbersWithUnits(strings.Join(lines, "\n"))
 }
 
 func taxesAndOtherRevenues(value string) (interface{}, error) {
 	value = strings.Replace(value, "% of GDP", " percent_of_gdp", -1)
-	return stringToNumberWithGlobalRankAndDate(value, "percent_of_gdp")
+	return stringToNumberWithGlobalRankAndDate(value, "percent_of_gdp", "%")
 }
 
 func budgetSurplusOrDeficit(value string) (interface{}, error) {
 	value = strings.Replace(value, "% of GDP", " percent_of_gdp", -1)
-	return stringToNumberWithGlobalRankAndDate(value, "percent_of_gdp")
+	return stringToNumberWithGlobalRankAndDate(value, "percent_of_gdp", "%")
 }
 
 func publicDebt(value string) (interface{}, error) {
@@ -2656,7 +2537,7 @@ func importExportsCommodities(value string) (interface{}, error) {
 	value = strings.Replace(value, "the whole range of industrial and agricultural goods and services", "", -1)
 	value = strings.Replace(value, "top ten - share of world trade: ", "", -1)
 	value = strings.Replace(value, "including ", ", ", -1)
-	value, date, hasDate := stringWithoutDate(value)
+	value, date, hasDate := stringWithoutDateDetail(value)
 	commodities, err := stringToList(value, listConditions{
 		splitChars: ",;",
 	})
@@ -2695,7 +2576,7 @@ func importExportsCommodities(value string) (interface{}, error) {
 		o.Set("note", note)
 	}
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	keys := o.Keys()
 	if len(keys) == 0 {
@@ -2736,8 +2617,7 @@ func stockOfDirectForeignInvestmentAbroad(value string) (interface{}, error) {
 }
 
 func exchangeRates(value string) (interface{}, error) {
-	value = strings.Replace(value, " -", "", -1)
-	return stringToListOfAnnualValues(value, "USD")
+	return stringToExchangeRates(value)
 }
 
 func economyOfTurkishCypriots(value string) (interface{}, error) {
@@ -2764,7 +2644,7 @@ func electricityAccess(value string) (interface{}, error) {
 	value = strings.Replace(value, "electrification - total population", "total electrification", -1)
 	value = strings.Replace(value, "electrification - urban areas", "urban electrification", -1)
 	value = strings.Replace(value, "electrification - rural areas", "rural electrification", -1)
-	value, date, hasDate := stringWithoutDate(value)
+	value, date, hasDate := stringWithoutDateDetail(value)
 	o, err := stringToMap(value)
 	if err != nil {
 		return o, err
@@ -2774,11 +2654,12 @@ func electricityAccess(value string) (interface{}, error) {
 		vInterface, _ := o.Get(k)
 		v := vInterface.(string)
 		if k == "population_without_electricity" {
-			vNum, err := stringToNumber(v)
+			vNum, err := stringToNumericValue(v)
 			if err == nil {
 				m := orderedmap.New()
-				m.Set("value", vNum)
+				m.Set("value", vNum.lowerBound)
 				m.Set("units", "people")
+				setNumericQualifiers(m, vNum)
 				o.Set(k, m)
 			}
 		} else {
@@ -2789,17 +2670,17 @@ func electricityAccess(value string) (interface{}, error) {
 		}
 	}
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
 
 func electricityTotalKwh(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "kWh")
+	return stringToNumberWithGlobalRankAndDate(value, "kWh", "kWh")
 }
 
 func electricityTotalKw(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "kW")
+	return stringToNumberWithGlobalRankAndDate(value, "kW", "kW")
 }
 
 func (p *Page) electricityFrom() (interface{}, error) {
@@ -2817,7 +2698,7 @@ func (p *Page) electricityFrom() (interface{}, error) {
 
 func electricityPercent(value string) (interface{}, error) {
 	value = strings.Replace(value, " of total installed capacity", "", -1)
-	return stringToNumberWithGlobalRankAndDate(value, "percent")
+	return stringToNumberWithGlobalRankAndDate(value, "percent", "%")
 }
 
 func (p *Page) crudeOil() (interface{}, error) {
@@ -2834,11 +2715,11 @@ func (p *Page) crudeOil() (interface{}, error) {
 }
 
 func crudeOilBblPerDay(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "bbl_per_day")
+	return stringToNumberWithGlobalRankAndDate(value, "bbl_per_day", "bbl/day")
 }
 
 func crudeOilBbl(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "bbl")
+	return stringToNumberWithGlobalRankAndDate(value, "bbl", "bbl")
 }
 
 func (p *Page) refinedPetroleumProducts() (interface{}, error) {
@@ -2869,11 +2750,11 @@ func (p *Page) naturalGas() (interface{}, error) {
 }
 
 func naturalGasCuM(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "cubic_metres")
+	return stringToNumberWithGlobalRankAndDate(value, "cubic_metres", "cu m")
 }
 
 func carbonDioxideEmissions(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "megatonnes")
+	return stringToNumberWithGlobalRankAndDate(value, "megatonnes", "Mt")
 }
 
 func (p *Page) telephones() (interface{}, error) {
@@ -2901,12 +2782,38 @@ func telephonesMobileCellular(value string) (interface{}, error) {
 	return stringToMapOfNumbers(value)
 }
 
+// The general assessment, domestic and international systems each have the
+// submarine cables, satellite earth stations and teledensity they name
 func telephoneSystem(value string) (interface{}, error) {
-	return stringToMap(value)
+	o, err := stringToMap(value)
+	if err != nil {
+		return o, err
+	}
+	for _, key := range telephoneSystemKeys {
+		v, exists := o.Get(key)
+		if !exists {
+			continue
+		}
+		s, isString := v.(string)
+		if isString {
+			o.Set(key, telephoneSystemDetails(s))
+		}
+	}
+	return o, nil
 }
 
 func broadcastMedia(value string) (interface{}, error) {
-	return value, nil
+	o := orderedmap.New()
+	o.Set("text", value)
+	stations := broadcastStations(value)
+	if len(stations) > 0 {
+		o.Set("stations", stations)
+		totals := broadcastStationTotals(stations)
+		if len(totals.Keys()) > 0 {
+			o.Set("station_totals", totals)
+		}
+	}
+	return o, nil
 }
 
 func radioBroacastStations(value string) (interface{}, error) {
@@ -2963,11 +2870,11 @@ func nationalAirTransportSystem(value string) (interface{}, error) {
 }
 
 func civilAircraftRegistrationCountryCodePrefix(value string) (interface{}, error) {
-	value, date, hasDate := stringWithoutDate(value)
+	value, date, hasDate := stringWithoutDateDetail(value)
 	o := orderedmap.New()
 	o.Set("prefix", strings.TrimSpace(value))
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
@@ -2977,6 +2884,12 @@ func (p *Page) airports() (interface{}, error) {
 	p.tryAddingDataForSelector(a, "total", Selector{"2053", "transportation-airports"}, airportsTotal)
 	p.tryAddingDataForSelector(a, "paved", Selector{"2030", "transportation-airports-with-paved-runways"}, airportsRunways)
 	p.tryAddingDataForSelector(a, "unpaved", Selector{"2031", "transportation-airports-with-unpaved-runways"}, airportsRunways)
+	paved, _ := a.Get("paved")
+	unpaved, _ := a.Get("unpaved")
+	byRunwayLength := mergeRunwayCounts(paved, unpaved)
+	if len(byRunwayLength) > 0 {
+		a.Set("by_runway_length", byRunwayLength)
+	}
 	keys := a.Keys()
 	if len(keys) == 0 {
 		return a, NoValueErr
@@ -2985,82 +2898,23 @@ func (p *Page) airports() (interface{}, error) {
 }
 
 func airportsTotal(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "airports")
+	return stringToNumberWithGlobalRankAndDate(value, "airports", "")
 }
 
 func airportsRunways(value string) (interface{}, error) {
-	value = strings.Replace(value, ",", "", -1)
-	value = strings.Replace(value, " m:", " metres:", -1)
-	return stringToMapOfNumbers(value)
+	return stringToRunwayCounts(value)
 }
 
 func heliports(value string) (interface{}, error) {
-	return stringToNumberWithGlobalRankAndDate(value, "total")
+	return stringToNumberWithGlobalRankAndDate(value, "total", "")
 }
 
 func pipelines(value string) (interface{}, error) {
-	value, date, hasDate := stringWithoutDate(value)
-	p := orderedmap.New()
-	ps := []*orderedmap.OrderedMap{}
-	bits := splitByCommaOrSemicolon(value)
-	for _, bit := range bits {
-		o := orderedmap.New()
-		bit = strings.TrimSpace(bit)
-		b := strings.Split(bit, " ")
-		if len(b) < 3 {
-			continue
-		}
-		// get type, length and units
-		pipeType := ""
-		units := ""
-		length := 0.0
-		hasParsedLength := false
-		lastItemWasLength := false
-		var err error
-		for _, thisBit := range b {
-			if !hasParsedLength {
-				length, err = stringToNumber(thisBit)
-				if err == nil {
-					hasParsedLength = true
-					lastItemWasLength = true
-				} else {
-					lastItemWasLength = false
-				}
-			} else {
-				if lastItemWasLength {
-					units = thisBit
-				} else {
-					pipeType = pipeType + " " + thisBit
-				}
-				lastItemWasLength = false
-			}
-		}
-		if !hasParsedLength {
-			continue
-		}
-		// type
-		o.Set("type", strings.TrimSpace(pipeType))
-		// length
-		o.Set("length", length)
-		// units
-		o.Set("units", units)
-		ps = append(ps, o)
-	}
-	if len(ps) > 0 {
-		p.Set("by_type", ps)
-	}
-	if hasDate {
-		p.Set("date", date)
-	}
-	keys := p.Keys()
-	if len(keys) == 0 {
-		return p, NoValueErr
-	}
-	return p, nil
+	return transportNetwork(stringToPipelineLengths(value))
 }
 
 func railways(value string) (interface{}, error) {
-	value, date, hasDate := stringWithoutDate(value)
+	value, date, hasDate := stringWithoutDateDetail(value)
 	o, err := stringToMap(value)
 	if err != nil {
 		return o, err
@@ -3070,11 +2924,11 @@ func railways(value string) (interface{}, error) {
 		value, _ := o.Get(key)
 		// global rank
 		if key == "country_comparison_to_the_world" {
-			vInt, err := stringToNumber(value.(string))
+			rank, err := stringToNumericValue(value.(string))
 			if err != nil {
 				continue
 			}
-			o.Set("global_rank", vInt)
+			o.Set("global_rank", rank.lowerBound)
 			continue
 		}
 		// rail value
@@ -3088,70 +2942,22 @@ func railways(value string) (interface{}, error) {
 	o.Delete("country_comparison_to_the_world")
 	// set date
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
 
 func roadways(value string) (interface{}, error) {
-	value, date, hasDate := stringWithoutDate(value)
-	o, err := stringToMapOfNumbersWithUnits(value)
-	if err != nil {
-		return o, err
-	}
-	// set date
-	if hasDate {
-		o.Set("date", date)
-	}
-	return o, nil
+	return transportNetwork(stringToTransportLengths(value))
 }
 
 func waterways(value string) (interface{}, error) {
-	// date
-	value, date, hasDate := stringWithoutDate(value)
-	// notes
-	value, ps := removeParenthesis(value)
-	// length
-	first, others := firstLine(value)
-	o, err := stringToNumberWithUnits(first)
-	if err != nil {
-		return o, err
-	}
-	// note
-	if len(ps) > 0 {
-		note := strings.Join(ps, "; ")
-		o.Set("note", note)
-	}
-	// other values such as global rank
-	m, err := stringToMap(others)
-	if err == nil {
-		keys := m.Keys()
-		for _, key := range keys {
-			v, _ := m.Get(key)
-			if key == "country_comparison_to_the_world" {
-				vInt, err := stringToNumber(v.(string))
-				if err == nil {
-					o.Set("global_rank", vInt)
-				}
-			} else {
-				o.Set(key, v.(string))
-			}
-		}
-	}
-	// date
-	if hasDate {
-		o.Set("date", date)
-	}
-	keys := o.Keys()
-	if len(keys) == 0 {
-		return o, NoValueErr
-	}
-	return o, nil
+	return transportNetwork(stringToTransportLengths(value))
 }
 
 func merchantMarine(value string) (interface{}, error) {
 	// date
-	value, date, hasDate := stringWithoutDate(value)
+	value, date, hasDate := stringWithoutDateDetail(value)
 	o, err := stringToMap(value)
 	if err != nil {
 		return o, err
@@ -3161,14 +2967,15 @@ func merchantMarine(value string) (interface{}, error) {
 		vInterface, _ := o.Get(key)
 		v := vInterface.(string)
 		if key == "country_comparison_to_the_world" {
-			vInt, err := stringToNumber(v)
+			rank, err := stringToNumericValue(v)
 			if err == nil {
-				o.Set("global_rank", vInt)
+				o.Set("global_rank", rank.lowerBound)
 			}
 		} else if key == "total" {
-			vInt, err := stringToNumber(v)
+			total, err := stringToNumericValue(v)
 			if err == nil {
-				o.Set(key, vInt)
+				o.Set(key, total.lowerBound)
+				setNumericQualifiersForKey(o, key, total)
 			}
 		} else if key == "by_type" {
 			m, err := stringToListOfCounts(v, "type")
@@ -3193,7 +3000,7 @@ func merchantMarine(value string) (interface{}, error) {
 	o.Delete("country_comparison_to_the_world")
 	// date
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	keys = o.Keys()
 	if len(keys) == 0 {
@@ -3212,7 +3019,7 @@ func portsAndTerminals(value string) (interface{}, error) {
 	value = strings.Replace(value, "\nAscension Island:", ", Ascension Island - ", -1)
 	value = strings.Replace(value, "\nTristan da Cunha:", ", Tristan da Cunha - ", -1)
 	// date
-	value, date, hasDate := stringWithoutDate(value)
+	value, date, hasDate := stringWithoutDateDetail(value)
 	o, err := stringToMap(value)
 	if err != nil {
 		return o, err
@@ -3290,7 +3097,7 @@ func portsAndTerminals(value string) (interface{}, error) {
 	o.Delete("country_comparison_to_the_world")
 	// date
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	keys = o.Keys()
 	if len(keys) == 0 {
@@ -3308,12 +3115,12 @@ func transportNote(value string) (interface{}, error) {
 }
 
 func militaryExpenditures(value string) (interface{}, error) {
-	return stringToListOfAnnualValues(value, "percent_of_gdp")
+	return stringToMilitaryExpenditures(value)
 }
 
 func militaryBranches(value string) (interface{}, error) {
 	o := orderedmap.New()
-	value, date, hasDate := stringWithoutDate(value)
+	value, date, hasDate := stringWithoutDateDetail(value)
 	l, err := stringToList(value, listConditions{
 		splitChars: ",;:",
 	})
@@ -3325,7 +3132,7 @@ func militaryBranches(value string) (interface{}, error) {
 	}
 	o.Set("by_name", l)
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
@@ -3343,24 +3150,25 @@ func (p *Page) militaryManpower() (interface{}, error) {
 }
 
 func manpowerNumbers(value string) (interface{}, error) {
-	return stringToMapOfNumbers(value)
+	return stringToManpower(value)
 }
 
 func militaryServiceAgeAndObligation(value string) (interface{}, error) {
 	o := orderedmap.New()
-	value, date, hasDate := stringWithoutDate(value)
-	bits, _ := stringToList(value, listConditions{
-		splitChars: " -",
-	})
-	if len(bits) > 0 {
-		ageNum, err := stringToNumber(bits[0])
-		if err == nil {
-			o.Set("years_of_age", ageNum)
-		}
+	value, date, hasDate := stringWithoutDateDetail(value)
+	rules := stringToMilitaryServiceRules(value)
+	if len(rules) > 0 {
+		o.Set("years_of_age", rules[0].minAge)
+	}
+	ruleMaps := []*orderedmap.OrderedMap{}
+	for _, r := range rules {
+		ruleMaps = append(ruleMaps, r.toMap())
 	}
+	o.Set("rules", ruleMaps)
+	o.Set("conscription_abolished", conscriptionAbolishedRe.MatchString(value))
 	o.Set("note", value)
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
@@ -3383,223 +3191,74 @@ func terrorismForeignBased(value string) (interface{}, error) {
 	return value, nil
 }
 
+// Each dispute has the original text, the GEC codes of the countries
+// named in the text, and a category.
 func disputes(value string) (interface{}, error) {
-	list := strings.Split(value, "; ")
+	list := []*orderedmap.OrderedMap{}
+	for _, text := range strings.Split(value, "; ") {
+		text = strings.TrimSpace(text)
+		if len(text) == 0 {
+			continue
+		}
+		d := orderedmap.New()
+		d.Set("text", text)
+		d.Set("countries", countriesInString(text))
+		d.Set("category", disputeCategory(text))
+		list = append(list, d)
+	}
+	if len(list) == 0 {
+		return list, NoValueErr
+	}
 	return list, nil
 }
 
-func refugees(value string) (interface{}, error) {
-	value = strings.Replace(value, " (country of origin)", "", -1)
-	value = strings.Replace(value, "IDPs", "internally_displaced_persons", -1)
-	value = strings.Replace(value, ");", "),", -1)
-	value = strings.Replace(value, string(rune(160)), " ", -1) // nbsp
-	value = strings.Replace(value, "more than ", "", -1) // Algeria
-	value = strings.Replace(value, ", mostly living", ") (mostly living", -1) // Algeria
-	value = strings.Replace(value, "claimed asylum,", "claimed asylumCOMMA", -1) // Algeria
-	value = strings.Replace(value, "recognized as refugees,", "recognized as refugeesCOMMA", -1) // Algeria
-	value = strings.Replace(value, "political crisis;", "political crisisSEMICOLON", -1) // Argentina
-	// handle very different format for Iran and Pakistan
-	if strings.Index(value, "undocumented)") > -1 {
-		return refugees2(value)
-	}
-	o, err := stringToMap(value)
-	if err != nil {
-		return o, err
-	}
-	keys := o.Keys()
-	for _, key := range keys {
-		vInterface, _ := o.Get(key)
-		v := vInterface.(string)
-		if key == "refugees" {
-			v, date, hasDate := stringWithoutDate(v)
-			byCountry, err := stringToListWithItemNotes(v, "people", "country_of_origin", false)
-			if err != nil {
-				continue
-			}
-			for i, _ := range byCountry {
-				countryInterface, hasCountry := byCountry[i].Get("country_of_origin")
-				if hasCountry {
-					country := countryInterface.(string)
-					bits := strings.Split(country, "; ")
-					if len(bits) == 2 {
-						byCountry[i].Set("country_of_origin", bits[0])
-						note := bits[1]
-						note = strings.Replace(note, "COMMA", ",", -1)
-						note = strings.Replace(note, "SEMICOLON", ";", -1)
-						byCountry[i].Set("note", note)
-					}
-				}
-			}
-			m := orderedmap.New()
-			m.Set("by_country", byCountry)
-			if hasDate {
-				m.Set("date", date)
-			}
-			o.Set(key, m)
-		} else if key == "internally_displaced_persons" {
-			m := orderedmap.New()
-			v, date, hasDate := stringWithoutDate(v)
-			v, ps := removeParenthesis(v)
-			v = strings.TrimSpace(v)
-			vNum, err := stringToNumber(v)
-			if err == nil {
-				m.Set("people", vNum)
-			} else {
-				ps = append([]string{v}, ps...)
-			}
-			if len(ps) > 0 {
-				note := strings.Join(ps, "; ")
-				m.Set("note", note)
-			}
-			if hasDate {
-				m.Set("date", date)
-			}
-			o.Set(key, m)
-		} else if key == "stateless_persons" {
-			m := orderedmap.New()
-			v, date, hasDate := stringWithoutDate(v)
-			vNum, err := stringToNumber(v)
-			if err == nil {
-				m.Set("people", vNum)
-			} else {
-				m.Set("note", v)
-			}
-			if hasDate {
-				m.Set("date", date)
+// Categories are checked in order, so maritime boundaries are maritime
+// rather than boundary disputes.
+var disputeCategories = []struct {
+	category string
+	words    []string
+}{
+	{"maritime", []string{"maritime", "sea", "seas", "continental shelf", "exclusive economic zone", "EEZ", "territorial waters", "fishing", "fisheries", "gulf", "bay"}},
+	{"water", []string{"water", "waters", "dam", "dams", "river water", "aquifer", "irrigation", "watershed"}},
+	{"territorial", []string{"claim", "claims", "claimed", "sovereignty", "island", "islands", "occupied", "occupies", "occupation", "territory", "annexed", "annexation"}},
+	{"boundary", []string{"boundary", "boundaries", "border", "borders", "demarcation", "demarcate", "delimitation", "delimit", "frontier"}},
+}
+
+// Returns maritime, water, territorial or boundary, or other if the text
+// matches none of them
+func disputeCategory(s string) string {
+	words := strings.ToLower(" " + nonLetterRe.ReplaceAllString(s, " ") + " ")
+	for _, c := range disputeCategories {
+		for _, w := range c.words {
+			if strings.Index(words, " "+strings.ToLower(w)+" ") > -1 {
+				return c.category
 			}
-			o.Set(key, m)
 		}
 	}
-	if len(keys) == 0 {
-		return o, NoValueErr
-	}
-	return o, nil
+	return "other"
 }
 
-func refugees2(value string) (interface{}, error) {
-	// format is
-	// 2.5-3.0 (1 million registered, 1.5-2.0 million undocumented) (Afghanistan) (2017)
-	// want to convert to
-	// 2,750,000 (Afghanistan) (1 million registeredCOMMA 1.5-2.0 million undocumented) (2017)
+func refugees(value string) (interface{}, error) {
+	return stringToRefugees(value)
+}
+
+func traffickingInPersons(value string) (interface{}, error) {
 	o, err := stringToMap(value)
 	if err != nil {
 		return o, err
 	}
-	keys := o.Keys()
-	for _, key := range keys {
-		vInterface, _ := o.Get(key)
-		v := vInterface.(string)
-		if key == "refugees" {
-			byCountry := []*orderedmap.OrderedMap{}
-			countries := strings.Split(v, "), ")
-			for i, refugeesByCountry := range countries {
-				if i < len(countries) - 1 {
-					refugeesByCountry = refugeesByCountry + ")"
-				}
-				refugees, date, hasDate := stringWithoutDate(refugeesByCountry)
-				peopleStr, ps := removeParenthesis(refugees)
-				people, err := stringToNumber(peopleStr)
-				if err != nil {
-					return o, err
-				}
-				bits := strings.Split(peopleStr, "-")
-				isRange := len(bits) == 2
-				if isRange {
-					lowerStr := bits[0]
-					upperStr := bits[1]
-					// check for units
-					upperBits := strings.Fields(upperStr)
-					if len(upperBits) == 2 {
-						upperUnits := upperBits[1]
-						lowerStr = lowerStr + " " + upperUnits
-					}
-					// parse upper and lower bounds
-					lower, err := stringToNumber(lowerStr)
-					if err != nil {
-						return o, err
-					}
-					upper, err := stringToNumber(upperStr)
-					if err != nil {
-						return o, err
-					}
-					// use average of upper and lower bounds
-					people = (lower + upper) / 2
-					if people < 1000 && strings.Index(v, "million") > 0 {
-						people = people * 1000000
-					}
-				}
-				country := ""
-				note := ""
-				if len(ps) == 1 {
-					country = ps[0]
-				}
-				if len(ps) == 2 {
-					note = ps[0]
-					country = ps[1]
-				}
-				item := orderedmap.New()
-				item.Set("people", people)
-				item.Set("country_of_origin", country)
-				if len(note) > 0 {
-					item.Set("note", note)
-				}
-				if hasDate {
-					item.Set("date", date)
-				}
-				byCountry = append(byCountry, item)
-			}
-			m := orderedmap.New()
-			m.Set("by_country", byCountry)
-			o.Set(key, m)
-		} else if key == "internally_displaced_persons" {
-			m := orderedmap.New()
-			v, date, hasDate := stringWithoutDate(v)
-			v, ps := removeParenthesis(v)
-			v = strings.TrimSpace(v)
-			vNum, err := stringToNumber(v)
-			if err == nil {
-				m.Set("people", vNum)
-			} else {
-				ps = append([]string{v}, ps...)
-			}
-			if len(ps) > 0 {
-				note := strings.Join(ps, "; ")
-				m.Set("note", note)
-			}
-			if hasDate {
-				m.Set("date", date)
-			}
-			o.Set(key, m)
-		} else if key == "stateless_persons" {
-			m := orderedmap.New()
-			v, date, hasDate := stringWithoutDate(v)
-			vNum, err := stringToNumber(v)
-			if err == nil {
-				m.Set("people", vNum)
-			} else {
-				m.Set("note", v)
-			}
-			if hasDate {
-				m.Set("date", date)
-			}
-			o.Set(key, m)
+	tierRating, exists := o.Get("tier_rating")
+	if exists {
+		rating, err := stringToTierRating(tierRating.(string))
+		if err == nil {
+			o.Set("tier_rating", rating)
 		}
 	}
-	if len(keys) == 0 {
-		return o, NoValueErr
-	}
 	return o, nil
 }
 
-func traffickingInPersons(value string) (interface{}, error) {
-	return stringToMap(value)
-}
-
 func illicitDrugs(value string) (interface{}, error) {
-	// See France
-	value = strings.Replace(value, "metropolitan France:", "note: metropolitan France - ", -1)
-	value = strings.Replace(value, "French Guiana:", "note: French Guiana - ", -1)
-	value = strings.Replace(value, "Martinique:", "note: Martinique - ", -1)
+	value = pageTerritoryValue(value)
 	if len(value) == 0 {
 		return value, NoValueErr
 	}
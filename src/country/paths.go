@@ -0,0 +1,53 @@
+package country
+
+import (
+	"orderedmap"
+)
+
+// Returns the value at the path of keys in parsed data, which may be from
+// Page.parse or from json
+func valueAtPath(v interface{}, keys ...string) (interface{}, bool) {
+	for _, key := range keys {
+		var exists bool
+		switch o := v.(type) {
+		case *orderedmap.OrderedMap:
+			v, exists = o.Get(key)
+		case orderedmap.OrderedMap:
+			v, exists = o.Get(key)
+		}
+		if !exists {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// Returns the number at the path of keys, eg the percent_of_gdp of the
+// budget surplus
+func floatAtPath(v interface{}, keys ...string) (float64, bool) {
+	value, exists := valueAtPath(v, keys...)
+	if !exists {
+		return 0, false
+	}
+	f, isFloat := value.(float64)
+	return f, isFloat
+}
+
+func stringAtPath(v interface{}, keys ...string) string {
+	value, _ := valueAtPath(v, keys...)
+	s, _ := value.(string)
+	return s
+}
+
+// Returns the map at the path of keys, or an empty map if there is no map
+// at that path
+func mapAtPath(v interface{}, keys ...string) *orderedmap.OrderedMap {
+	value, _ := valueAtPath(v, keys...)
+	switch m := value.(type) {
+	case *orderedmap.OrderedMap:
+		return m
+	case orderedmap.OrderedMap:
+		return &m
+	}
+	return orderedmap.New()
+}
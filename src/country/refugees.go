@@ -0,0 +1,271 @@
+package country
+
+import (
+	"orderedmap"
+	"regexp"
+	"strings"
+)
+
+// Keys in the refugees field, eg "refugees (country of origin):", "IDPs:"
+var refugeeKeyRe = regexp.MustCompile(`^(refugees(?:\s*\(country of origin\))?|IDPs|internally displaced persons|stateless persons|notes?)\s*:\s*`)
+
+// eg "... (2007) IDPs: 50,000" where the new line is missing
+var refugeeInlineKeyRe = regexp.MustCompile(`[ \t]+(IDPs:|stateless persons:)`)
+
+var refugeeCategoryForKey = map[string]string{
+	"refugees":                     "refugees",
+	"refugees (country of origin)": "refugees",
+	"IDPs":                         "internally_displaced_persons",
+	"internally displaced persons": "internally_displaced_persons",
+	"stateless persons":            "stateless_persons",
+}
+
+// refugeeToken is the text outside parenthesis or the text inside one
+// parenthesis of a refugees item
+type refugeeToken struct {
+	text          string
+	isParenthesis bool
+}
+
+// refugeeRecord is the number of refugees from one country of origin, or the
+// number of internally displaced or stateless persons
+type refugeeRecord struct {
+	category        string
+	countryOfOrigin string
+	count           numericValue
+	hasCount        bool
+	date            factbookDate
+	hasDate         bool
+	notes           []string
+}
+
+func (r refugeeRecord) toMap() *orderedmap.OrderedMap {
+	o := orderedmap.New()
+	o.Set("category", r.category)
+	if len(r.countryOfOrigin) > 0 {
+		o.Set("country_of_origin", r.countryOfOrigin)
+	}
+	if r.hasCount {
+		count := r.count.lowerBound
+		// ranges use the middle of the range, eg 1.5-2.0 million
+		if r.count.hasUpperBound {
+			count = (r.count.lowerBound + r.count.upperBound) / 2
+		}
+		o.Set("count", count)
+		setNumericQualifiers(o, r.count)
+	}
+	if r.hasDate {
+		setDate(o, r.date)
+	}
+	if len(r.notes) > 0 {
+		o.Set("note", strings.Join(r.notes, "; "))
+	}
+	return o
+}
+
+// Splits an item into the text outside parenthesis and the text in each
+// parenthesis, in order. Nested parenthesis are kept in the outer token.
+func tokeniseRefugeeItem(s string) []refugeeToken {
+	tokens := []refugeeToken{}
+	current := ""
+	depth := 0
+	for _, c := range s {
+		if c == '(' {
+			if depth == 0 {
+				if len(strings.TrimSpace(current)) > 0 {
+					tokens = append(tokens, refugeeToken{strings.TrimSpace(current), false})
+				}
+				current = ""
+			} else {
+				current = current + string(c)
+			}
+			depth = depth + 1
+		} else if c == ')' && depth > 0 {
+			depth = depth - 1
+			if depth == 0 {
+				tokens = append(tokens, refugeeToken{strings.TrimSpace(current), true})
+				current = ""
+			} else {
+				current = current + string(c)
+			}
+		} else {
+			current = current + string(c)
+		}
+	}
+	if len(strings.TrimSpace(current)) > 0 {
+		tokens = append(tokens, refugeeToken{strings.TrimSpace(current), depth > 0})
+	}
+	return tokens
+}
+
+// The country of origin is a name in parenthesis, eg (Afghanistan) or
+// (Western Saharan Sahrawi, mostly living in camps ...), unlike notes such as
+// (1 million registered, 1.5-2.0 million undocumented) or (political crisis)
+func isCountryOfOrigin(s string) bool {
+	return startsWithCapitalLetter(s) && !strings.ContainsAny(s, "0123456789")
+}
+
+// Returns the record for one item, eg "7,785 (Afghanistan)", "2.5-3.0 (1
+// million registered, 1.5-2.0 million undocumented) (Afghanistan) (2017)" or
+// "1.2 million (conflict in the north) (2016)"
+func stringToRefugeeRecord(category, item string) (refugeeRecord, bool) {
+	r := refugeeRecord{category: category}
+	item, r.date, r.hasDate = stringWithoutDateDetail(item)
+	countStrs := []string{}
+	for _, t := range tokeniseRefugeeItem(item) {
+		if !t.isParenthesis {
+			countStrs = append(countStrs, t.text)
+			continue
+		}
+		if category == "refugees" && len(r.countryOfOrigin) == 0 && isCountryOfOrigin(t.text) {
+			bits := splitOnCommaSpace(strings.Replace(t.text, "; ", ", ", -1))
+			r.countryOfOrigin = strings.TrimSpace(bits[0])
+			if len(bits) > 1 {
+				r.notes = append(r.notes, strings.TrimSpace(strings.Join(bits[1:], ", ")))
+			}
+			continue
+		}
+		if len(t.text) > 0 {
+			r.notes = append(r.notes, t.text)
+		}
+	}
+	countStr := strings.Join(countStrs, " ")
+	n, err := stringToNumericValue(countStr)
+	if err == nil {
+		r.count = n
+		r.hasCount = true
+		// Iran and Pakistan give the range in millions in the note, eg 2.5-3.0
+		// (1 million registered, 1.5-2.0 million undocumented)
+		noteHasMillions := strings.Index(strings.Join(r.notes, " "), "million") > -1
+		if numberMagnitude(countStr) == 1 && n.lowerBound < 1000 && noteHasMillions {
+			r.count.lowerBound = n.lowerBound * 1e6
+			r.count.upperBound = n.upperBound * 1e6
+		}
+	} else if len(countStr) > 0 {
+		// eg undetermined
+		r.notes = append([]string{countStr}, r.notes...)
+	}
+	return r, r.hasCount || len(r.countryOfOrigin) > 0
+}
+
+// Items are separated by commas or semicolons outside parenthesis, or by new
+// lines. Commas in numbers are kept, as is the rest of a line after a note,
+// eg "75,000 (2016); note - registered only, some undocumented"
+func refugeeItems(s string) []string {
+	items := []string{}
+	for _, line := range strings.Split(s, "\n") {
+		parts := splitIgnoringParenthesis(line, ';')
+		for i, part := range parts {
+			if transportNoteRe.MatchString(strings.TrimSpace(part)) {
+				items = append(items, strings.TrimSpace(strings.Join(parts[i:], ";")))
+				break
+			}
+			for _, item := range splitOnCommaSpace(part) {
+				item = strings.TrimSpace(item)
+				if len(item) > 0 {
+					items = append(items, item)
+				}
+			}
+		}
+	}
+	return items
+}
+
+// Returns a record for each country of origin of refugees and for the
+// internally displaced and stateless persons, eg
+// refugees (country of origin): 7,785 (Afghanistan); 5,201 (Iran) (2015)
+// IDPs: 40,000 (2016)
+// stateless persons: 10,000 (2016)
+// An item without a date takes the date at the end of its list.
+func stringToRefugees(value string) (*orderedmap.OrderedMap, error) {
+	o := orderedmap.New()
+	value = strings.Replace(value, string(rune(160)), " ", -1) // nbsp
+	// some pages have every key on one line
+	value = refugeeInlineKeyRe.ReplaceAllString(value, "\n$1")
+	sections := []string{}
+	textForSection := map[string]string{}
+	notes := []string{}
+	currentKey := ""
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		m := refugeeKeyRe.FindStringSubmatch(line)
+		if m != nil {
+			currentKey = m[1]
+			line = line[len(m[0]):]
+			if _, exists := textForSection[currentKey]; !exists {
+				sections = append(sections, currentKey)
+			}
+		}
+		if len(currentKey) == 0 {
+			notes = append(notes, line)
+			continue
+		}
+		textForSection[currentKey] = strings.TrimSpace(textForSection[currentKey] + "\n" + line)
+	}
+	records := []refugeeRecord{}
+	for _, key := range sections {
+		text := textForSection[key]
+		category, isCategory := refugeeCategoryForKey[key]
+		if !isCategory {
+			notes = append(notes, strings.Replace(text, "\n", " ", -1))
+			continue
+		}
+		sectionRecords := []refugeeRecord{}
+		for _, item := range refugeeItems(text) {
+			if transportNoteRe.MatchString(item) {
+				notes = append(notes, transportNoteRe.ReplaceAllString(item, ""))
+				continue
+			}
+			r, isRecord := stringToRefugeeRecord(category, item)
+			if isRecord {
+				sectionRecords = append(sectionRecords, r)
+				continue
+			}
+			// text between items, eg "mostly in the north", belongs to the
+			// item before it
+			if len(sectionRecords) > 0 {
+				last := &sectionRecords[len(sectionRecords)-1]
+				last.notes = append(last.notes, r.notes...)
+				if r.hasDate && !last.hasDate {
+					last.date = r.date
+					last.hasDate = true
+				}
+				continue
+			}
+			// eg IDPs: undetermined (conflict in the north)
+			if len(r.notes) > 0 {
+				sectionRecords = append(sectionRecords, r)
+			}
+		}
+		for i := len(sectionRecords) - 1; i >= 0; i-- {
+			if !sectionRecords[i].hasDate {
+				continue
+			}
+			for j := 0; j < i; j++ {
+				if !sectionRecords[j].hasDate {
+					sectionRecords[j].date = sectionRecords[i].date
+					sectionRecords[j].hasDate = true
+				}
+			}
+			break
+		}
+		records = append(records, sectionRecords...)
+	}
+	if len(records) > 0 {
+		recordMaps := []*orderedmap.OrderedMap{}
+		for _, r := range records {
+			recordMaps = append(recordMaps, r.toMap())
+		}
+		o.Set("records", recordMaps)
+	}
+	if len(notes) > 0 {
+		o.Set("note", strings.Join(notes, "; "))
+	}
+	if len(o.Keys()) == 0 {
+		return o, NoValueErr
+	}
+	return o, nil
+}
@@ -0,0 +1,150 @@
+package country
+
+import (
+	"orderedmap"
+	"testing"
+)
+
+type RefugeeRecordExpectation struct {
+	category        string
+	countryOfOrigin string
+	count           interface{}
+	date            string
+	note            string
+}
+
+type RefugeesCase struct {
+	s               string
+	expectedRecords []RefugeeRecordExpectation
+	expectedNote    string
+}
+
+var refugeesCases = []RefugeesCase{
+	// list of countries of origin with the date at the end
+	RefugeesCase{
+		s: "refugees (country of origin): 7,785 (Afghanistan); 5,201 (Iran) (2015)",
+		expectedRecords: []RefugeeRecordExpectation{
+			{"refugees", "Afghanistan", 7785.0, "2015", ""},
+			{"refugees", "Iran", 5201.0, "2015", ""},
+		},
+	},
+	// Iran and Pakistan, range in millions given by the note
+	RefugeesCase{
+		s: "refugees (country of origin): 2.5-3.0 (1 million registered, 1.5-2.0 million undocumented) (Afghanistan), 28,268 (Iraq) (2017)",
+		expectedRecords: []RefugeeRecordExpectation{
+			{"refugees", "Afghanistan", 2750000.0, "2017", "1 million registered, 1.5-2.0 million undocumented"},
+			{"refugees", "Iraq", 28268.0, "2017", ""},
+		},
+	},
+	// Algeria, note with the country of origin
+	RefugeesCase{
+		s: "refugees (country of origin): more than 90,000 (Western Saharan Sahrawi, mostly living in Algerian-sponsored camps in the southwestern Algerian town of Tindouf); 4,000 (Syria) (more than 40,000 claimed asylum, 4,000 recognized as refugees) (2016)",
+		expectedRecords: []RefugeeRecordExpectation{
+			{"refugees", "Western Saharan Sahrawi", 90000.0, "2016", "mostly living in Algerian-sponsored camps in the southwestern Algerian town of Tindouf"},
+			{"refugees", "Syria", 4000.0, "2016", "more than 40,000 claimed asylum, 4,000 recognized as refugees"},
+		},
+	},
+	// Argentina, semicolon in the note
+	RefugeesCase{
+		s: "refugees (country of origin): 145,000 (Venezuela) (economic and political crisis; includes Venezuelans who have claimed asylum or have received alternative legal stay) (2019)",
+		expectedRecords: []RefugeeRecordExpectation{
+			{"refugees", "Venezuela", 145000.0, "2019", "economic and political crisis; includes Venezuelans who have claimed asylum or have received alternative legal stay"},
+		},
+	},
+	// refugees listed one per line
+	RefugeesCase{
+		s: "refugees (country of origin):\n7,785 (Afghanistan)\n5,201 (Iran) (2015)",
+		expectedRecords: []RefugeeRecordExpectation{
+			{"refugees", "Afghanistan", 7785.0, "2015", ""},
+			{"refugees", "Iran", 5201.0, "2015", ""},
+		},
+	},
+	// IDPs and stateless persons
+	RefugeesCase{
+		s: "refugees (country of origin): 15,000 (Somalia) (2016)\nIDPs: 228,000 (both Turkish and Greek Cypriots; many displaced for over 30 years) (2016)\nstateless persons: 10,000 (2016)",
+		expectedRecords: []RefugeeRecordExpectation{
+			{"refugees", "Somalia", 15000.0, "2016", ""},
+			{"internally_displaced_persons", "", 228000.0, "2016", "both Turkish and Greek Cypriots; many displaced for over 30 years"},
+			{"stateless_persons", "", 10000.0, "2016", ""},
+		},
+	},
+	// IDPs in millions, and without a count
+	RefugeesCase{
+		s: "IDPs: 1.2 million (ongoing conflict since 2016) (2018)",
+		expectedRecords: []RefugeeRecordExpectation{
+			{"internally_displaced_persons", "", 1200000.0, "2018", "ongoing conflict since 2016"},
+		},
+	},
+	RefugeesCase{
+		s: "IDPs: undetermined (conflict in the north) (2016)",
+		expectedRecords: []RefugeeRecordExpectation{
+			{"internally_displaced_persons", "", nil, "2016", "undetermined; conflict in the north"},
+		},
+	},
+	// note after the count
+	RefugeesCase{
+		s: "stateless persons: 480,695 (2019); note - estimate represents stateless persons registered with the Thai Government, the actual number may be as high as 3.5 million",
+		expectedRecords: []RefugeeRecordExpectation{
+			{"stateless_persons", "", 480695.0, "2019", ""},
+		},
+		expectedNote: "estimate represents stateless persons registered with the Thai Government, the actual number may be as high as 3.5 million",
+	},
+	// older pages with the keys on one line
+	RefugeesCase{
+		s: "refugees (country of origin): 10,000 (Liberia) IDPs: 50,000 (2007)",
+		expectedRecords: []RefugeeRecordExpectation{
+			{"refugees", "Liberia", 10000.0, "", ""},
+			{"internally_displaced_persons", "", 50000.0, "2007", ""},
+		},
+	},
+	// IDPs given as a range, with a non breaking space
+	RefugeesCase{
+		s: "IDPs:" + string(rune(160)) + "200,000-250,000 (2007)",
+		expectedRecords: []RefugeeRecordExpectation{
+			{"internally_displaced_persons", "", 225000.0, "2007", ""},
+		},
+	},
+}
+
+func TestStringToRefugees(t *testing.T) {
+	for testIndex, c := range refugeesCases {
+		o, err := stringToRefugees(c.s)
+		if err != nil {
+			t.Error("stringToRefugees error, testIndex: ", testIndex, err)
+			continue
+		}
+		recordsInterface, _ := o.Get("records")
+		records, _ := recordsInterface.([]*orderedmap.OrderedMap)
+		if len(records) != len(c.expectedRecords) {
+			t.Error("stringToRefugees records, testIndex: ", testIndex, len(records))
+			continue
+		}
+		for i, expected := range c.expectedRecords {
+			r := records[i]
+			category, _ := r.Get("category")
+			countryOfOrigin, _ := r.Get("country_of_origin")
+			count, _ := r.Get("count")
+			date, _ := r.Get("date")
+			note, _ := r.Get("note")
+			if category != expected.category {
+				t.Error("stringToRefugees category, testIndex: ", testIndex, i, category)
+			}
+			if expected.countryOfOrigin != "" && countryOfOrigin != expected.countryOfOrigin {
+				t.Error("stringToRefugees country_of_origin, testIndex: ", testIndex, i, countryOfOrigin)
+			}
+			if count != expected.count {
+				t.Error("stringToRefugees count, testIndex: ", testIndex, i, count)
+			}
+			if expected.date != "" && date != expected.date {
+				t.Error("stringToRefugees date, testIndex: ", testIndex, i, date)
+			}
+			if expected.note != "" && note != expected.note {
+				t.Error("stringToRefugees note, testIndex: ", testIndex, i, note)
+			}
+		}
+		note, _ := o.Get("note")
+		if c.expectedNote != "" && note != c.expectedNote {
+			t.Error("stringToRefugees note, testIndex: ", testIndex, note)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package country
+
+import (
+	"errors"
+)
+
+var NoRegionError = errors.New("No region found")
+
+// region is one of the regions of the factbook and the GEC codes of the
+// entities listed on its archived region page, eg
+// wfbExt/region_cam.html for Central America and Caribbean.
+// The world is not in a region.
+type region struct {
+	code string
+	name string
+	gecs []string
+}
+
+var regionsList = []region{
+	region{"afr", "Africa", []string{
+		"ag", "ao", "bn", "bc", "uv", "by", "cv", "cm", "ct", "cd", "cn", "cf",
+		"cg", "iv", "dj", "eg", "ek", "er", "wz", "et", "gb", "ga", "gh", "gv",
+		"pu", "ke", "lt", "li", "ly", "ma", "mi", "ml", "mr", "mp", "mf", "mo",
+		"mz", "wa", "ng", "ni", "re", "rw", "sh", "tp", "sg", "se", "sl", "so",
+		"sf", "od", "su", "tz", "to", "ts", "ug", "wi", "za", "zi",
+		// Iles Eparses
+		"bs", "eu", "go", "ju", "te",
+	}},
+	region{"ant", "Antarctica", []string{
+		"ay", "bv", "fs", "hm",
+	}},
+	region{"aus", "Australia - Oceania", []string{
+		"aq", "as", "at", "kt", "ck", "cw", "cr", "fj", "fp", "gq", "kr", "rm",
+		"fm", "nr", "nc", "nz", "ne", "nf", "cq", "ps", "pp", "pc", "ws", "bp",
+		"tl", "tn", "tv", "um", "nh", "wf", "wq",
+		// United States Pacific Island Wildlife Refuges before they were
+		// combined
+		"fq", "hq", "dq", "jq", "kq", "lq", "mq",
+	}},
+	region{"cam", "Central America and Caribbean", []string{
+		"av", "ac", "aa", "bf", "bb", "bh", "vi", "cj", "cs", "cu", "uc", "do",
+		"dr", "es", "gj", "gp", "gt", "ha", "ho", "jm", "mb", "mh", "bq", "nt",
+		"nu", "pm", "rq", "tb", "sc", "st", "rn", "vc", "nn", "td", "tk", "vq",
+	}},
+	region{"cas", "Central Asia", []string{
+		"kz", "kg", "rs", "ti", "tx", "uz",
+	}},
+	region{"eas", "East and Southeast Asia", []string{
+		"bx", "bm", "cb", "ch", "hk", "id", "ja", "kn", "ks", "la", "mc", "my",
+		"mg", "pf", "rp", "sn", "pg", "tw", "th", "tt", "vm",
+	}},
+	region{"eur", "Europe", []string{
+		"al", "an", "au", "bo", "be", "bk", "bu", "hr", "ez", "da", "en", "fo",
+		"fi", "fr", "gm", "gi", "gr", "gk", "hu", "ic", "ei", "im", "it", "je",
+		"kv", "lg", "ls", "lh", "lu", "mk", "mt", "md", "mn", "mj", "nl", "no",
+		"pl", "po", "ro", "sm", "rb", "ri", "yi", "lo", "si", "sp", "sv", "sw",
+		"sz", "up", "uk", "vt", "jn", "ee",
+	}},
+	region{"mde", "Middle East", []string{
+		"ax", "am", "aj", "ba", "cy", "dx", "gz", "gg", "ir", "iz", "is", "jo",
+		"ku", "le", "mu", "qa", "sa", "sy", "tu", "ae", "we", "ym",
+	}},
+	region{"noa", "North America", []string{
+		"bd", "ca", "ip", "gl", "mx", "sb", "us",
+	}},
+	region{"oce", "Oceans", []string{
+		"xq", "zh", "xo", "zn", "oo",
+	}},
+	region{"sam", "South America", []string{
+		"ar", "bl", "br", "ci", "co", "ec", "fk", "fg", "gy", "pa", "pe", "sx",
+		"ns", "uy", "ve",
+	}},
+	region{"soa", "South Asia", []string{
+		"af", "bg", "bt", "io", "in", "mv", "np", "pk", "ce",
+	}},
+}
+
+var regionForGec = map[string]region{}
+var regionForCode = map[string]region{}
+
+func init() {
+	for _, r := range regionsList {
+		regionForCode[r.code] = r
+		for _, gec := range r.gecs {
+			regionForGec[gec] = r
+		}
+	}
+}
+
+// Returns the region name and code for a GEC code, eg Central America and
+// Caribbean, cam for Cuba. Used for pages which do not name their region,
+// see regionFromDom.
+func regionForCodes(c Codes) (string, string, error) {
+	r, exists := regionForGec[c.Gec]
+	if !exists {
+		return "", "", NoRegionError
+	}
+	return r.name, r.code, nil
+}
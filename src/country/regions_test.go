@@ -0,0 +1,93 @@
+package country
+
+import (
+	"github.com/PuerkitoBio/goquery"
+	"strings"
+	"testing"
+)
+
+func TestRegionForCodes(t *testing.T) {
+	// every entity is in one region, except the world
+	seen := map[string]string{}
+	for _, r := range regionsList {
+		for _, gec := range r.gecs {
+			if other, exists := seen[gec]; exists {
+				t.Error("gec in more than one region", gec, other, r.code)
+			}
+			seen[gec] = r.code
+			if _, exists := codesByGec[gec]; !exists {
+				t.Error("region gec is not in the codes list", gec, r.code)
+			}
+		}
+	}
+	for _, c := range codesList {
+		_, _, err := regionForCodes(c)
+		if c.Gec == "xx" {
+			if err != NoRegionError {
+				t.Error("the world should not be in a region")
+			}
+			continue
+		}
+		if err != nil {
+			t.Error("no region for gec", c.Gec, c.Name)
+		}
+	}
+	cuba, _ := CodesForFilename("cu.html")
+	name, code, err := regionForCodes(cuba)
+	if err != nil || name != "Central America and Caribbean" || code != "cam" {
+		t.Error("region for cuba", name, code, err)
+	}
+	// historical codes are in the region of their entity
+	rb, _ := CodesForFilename("rb.html")
+	_, code, _ = regionForCodes(rb)
+	if code != "eur" {
+		t.Error("region for historical serbia", code)
+	}
+}
+
+type RegionFromDomCase struct {
+	html           string
+	expectedName   string
+	expectedCode   string
+	expectedSource string
+	expectedError  error
+}
+
+var regionFromDomCases = []RegionFromDomCase{
+	// link to the region page
+	RegionFromDomCase{
+		html:           `<a href="../wfbExt/region_cam.html">Central America and Caribbean</a>`,
+		expectedName:   "Central America and Caribbean",
+		expectedCode:   "cam",
+		expectedSource: "region_link",
+	},
+	// region code in the style of each category on older pages
+	RegionFromDomCase{
+		html:           `<div id='field' class='category aus_light'>National holiday:</div>`,
+		expectedName:   "Australia - Oceania",
+		expectedCode:   "aus",
+		expectedSource: "category_style",
+	},
+	RegionFromDomCase{
+		html:          `<div class='category'>National holiday:</div>`,
+		expectedError: NoRegionError,
+	},
+}
+
+func TestRegionFromDom(t *testing.T) {
+	for testIndex, c := range regionFromDomCases {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(c.html))
+		if err != nil {
+			t.Error("regionFromDom html error, testIndex: ", testIndex, err)
+			continue
+		}
+		name, code, source, err := regionFromDom(doc)
+		if err != c.expectedError {
+			t.Error("regionFromDom error, testIndex: ", testIndex, err)
+			continue
+		}
+		if name != c.expectedName || code != c.expectedCode || source != c.expectedSource {
+			t.Error("regionFromDom region, testIndex: ", testIndex, name, code, source)
+		}
+	}
+}
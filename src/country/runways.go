@@ -0,0 +1,191 @@
+package country
+
+import (
+	"errors"
+	"math"
+	"orderedmap"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var StringToRunwayLengthErr = errors.New("String is not a runway length")
+
+// eg over 3,047 m, 2,438 to 3,047 m, under 914 m
+var runwayLengthRe = regexp.MustCompile(`^(over|more than|under|less than)?\s*([0-9]+)\s*(?:(?:to|-)\s*([0-9]+))?\s*(m|metres|meters|ft|feet)$`)
+
+// eg over_3_047_metres in weekly files created before the buckets were typed
+var underscoreInNumberRe = regexp.MustCompile(`([0-9])_([0-9])`)
+
+// RunwayLength is a runway length bucket in metres. Buckets under a length
+// have a MinMetres of 0 and buckets over a length have no maximum.
+type RunwayLength struct {
+	MinMetres int
+	MaxMetres int
+	HasMax    bool
+}
+
+// Key is the same for a bucket in any layout, eg over_3047_m
+func (r RunwayLength) Key() string {
+	if !r.HasMax {
+		return "over_" + strconv.Itoa(r.MinMetres) + "_m"
+	}
+	if r.MinMetres == 0 {
+		return "under_" + strconv.Itoa(r.MaxMetres) + "_m"
+	}
+	return strconv.Itoa(r.MinMetres) + "_to_" + strconv.Itoa(r.MaxMetres) + "_m"
+}
+
+func (r RunwayLength) setOn(o *orderedmap.OrderedMap) {
+	o.Set("min_metres", r.MinMetres)
+	if r.HasMax {
+		o.Set("max_metres", r.MaxMetres)
+	}
+}
+
+func lengthInMetres(s, units string) int {
+	n, _ := strconv.Atoi(s)
+	if units == "ft" || units == "feet" {
+		return int(math.Floor(float64(n)*0.3048 + 0.5))
+	}
+	return n
+}
+
+// Converts the bucket in the airports field to a runway length, eg
+// "1,524 to 2,437 m". Keys from older weekly files such as
+// 1_524_to_2_437_metres are also converted.
+func StringToRunwayLength(s string) (RunwayLength, error) {
+	r := RunwayLength{}
+	s = strings.TrimSpace(strings.ToLower(s))
+	s = underscoreInNumberRe.ReplaceAllString(s, "$1$2")
+	s = strings.Replace(s, "_", " ", -1)
+	s = strings.Replace(s, ",", "", -1)
+	m := runwayLengthRe.FindStringSubmatch(s)
+	if m == nil {
+		return r, StringToRunwayLengthErr
+	}
+	units := m[4]
+	switch {
+	case m[1] == "over" || m[1] == "more than":
+		r.MinMetres = lengthInMetres(m[2], units)
+	case m[1] == "under" || m[1] == "less than":
+		r.MaxMetres = lengthInMetres(m[2], units)
+		r.HasMax = true
+	case len(m[3]) > 0:
+		r.MinMetres = lengthInMetres(m[2], units)
+		r.MaxMetres = lengthInMetres(m[3], units)
+		r.HasMax = true
+	default:
+		return r, StringToRunwayLengthErr
+	}
+	return r, nil
+}
+
+// Returns the total and the count for each runway length, eg
+// "total: 5,054\nover 3,047 m: 189\n2,438 to 3,047 m: 235 (2013)"
+func stringToRunwayCounts(value string) (*orderedmap.OrderedMap, error) {
+	o := orderedmap.New()
+	value, date, hasDate := stringWithoutDateDetail(value)
+	byLength := []*orderedmap.OrderedMap{}
+	notes := []string{}
+	for _, line := range strings.Split(value, "\n") {
+		bits := strings.SplitN(line, ":", 2)
+		if len(bits) != 2 {
+			continue
+		}
+		key := strings.TrimSpace(bits[0])
+		countStr := strings.TrimSpace(strings.Replace(bits[1], ",", "", -1))
+		if key == "note" {
+			notes = append(notes, countStr)
+			continue
+		}
+		count, err := strconv.Atoi(countStr)
+		if err != nil {
+			continue
+		}
+		if key == "total" {
+			o.Set("total", count)
+			continue
+		}
+		r, err := StringToRunwayLength(key)
+		if err != nil {
+			continue
+		}
+		b := orderedmap.New()
+		r.setOn(b)
+		b.Set("count", count)
+		byLength = append(byLength, b)
+	}
+	if len(byLength) > 0 {
+		o.Set("by_runway_length", byLength)
+	}
+	if len(notes) > 0 {
+		o.Set("note", strings.Join(notes, "; "))
+	}
+	if len(o.Keys()) == 0 {
+		return o, NoValueErr
+	}
+	if hasDate {
+		setDate(o, date)
+	}
+	return o, nil
+}
+
+func runwayLengthFromMap(o *orderedmap.OrderedMap) RunwayLength {
+	r := RunwayLength{}
+	minMetres, _ := o.Get("min_metres")
+	r.MinMetres, _ = minMetres.(int)
+	maxMetres, hasMax := o.Get("max_metres")
+	if hasMax {
+		r.MaxMetres, _ = maxMetres.(int)
+		r.HasMax = true
+	}
+	return r
+}
+
+// Merges the paved and unpaved counts into one table with a row for each
+// runway length, longest first
+func mergeRunwayCounts(paved, unpaved interface{}) []*orderedmap.OrderedMap {
+	lengths := map[string]RunwayLength{}
+	counts := map[string]map[string]int{}
+	sources := map[string]interface{}{"paved": paved, "unpaved": unpaved}
+	for _, surface := range []string{"paved", "unpaved"} {
+		o, isMap := sources[surface].(*orderedmap.OrderedMap)
+		if !isMap {
+			continue
+		}
+		list, _ := o.Get("by_runway_length")
+		rows, _ := list.([]*orderedmap.OrderedMap)
+		for _, row := range rows {
+			r := runwayLengthFromMap(row)
+			count, _ := row.Get("count")
+			if _, exists := counts[r.Key()]; !exists {
+				counts[r.Key()] = map[string]int{}
+				lengths[r.Key()] = r
+			}
+			counts[r.Key()][surface], _ = count.(int)
+		}
+	}
+	keys := []string{}
+	for key := range lengths {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := lengths[keys[i]], lengths[keys[j]]
+		if a.MinMetres != b.MinMetres {
+			return a.MinMetres > b.MinMetres
+		}
+		return a.MaxMetres > b.MaxMetres
+	})
+	merged := []*orderedmap.OrderedMap{}
+	for _, key := range keys {
+		row := orderedmap.New()
+		lengths[key].setOn(row)
+		row.Set("paved", counts[key]["paved"])
+		row.Set("unpaved", counts[key]["unpaved"])
+		row.Set("total", counts[key]["paved"]+counts[key]["unpaved"])
+		merged = append(merged, row)
+	}
+	return merged
+}
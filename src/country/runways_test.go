@@ -0,0 +1,78 @@
+package country
+
+import (
+	"orderedmap"
+	"testing"
+)
+
+type RunwayLengthCase struct {
+	s           string
+	expectedKey string
+}
+
+var runwayLengthCases = []RunwayLengthCase{
+	RunwayLengthCase{"over 3,047 m", "over_3047_m"},
+	RunwayLengthCase{"2,438 to 3,047 m", "2438_to_3047_m"},
+	RunwayLengthCase{"under 914 m", "under_914_m"},
+	// weekly files created before the buckets were typed
+	RunwayLengthCase{"1_524_to_2_437_metres", "1524_to_2437_m"},
+	RunwayLengthCase{"over_3_047_metres", "over_3047_m"},
+	RunwayLengthCase{"over 10,000 ft", "over_3048_m"},
+}
+
+func TestStringToRunwayLength(t *testing.T) {
+	for testIndex, c := range runwayLengthCases {
+		r, err := StringToRunwayLength(c.s)
+		if err != nil {
+			t.Error("StringToRunwayLength error, testIndex: ", testIndex, err)
+			continue
+		}
+		if r.Key() != c.expectedKey {
+			t.Error("StringToRunwayLength key, testIndex: ", testIndex, r.Key())
+		}
+	}
+	_, err := StringToRunwayLength("total")
+	if err != StringToRunwayLengthErr {
+		t.Error("StringToRunwayLength total", err)
+	}
+}
+
+func TestMergeRunwayCounts(t *testing.T) {
+	paved, err := stringToRunwayCounts("total: 5,054\nover 3,047 m: 189\n2,438 to 3,047 m: 235\nunder 914 m: 1,478 (2013)")
+	if err != nil {
+		t.Error("stringToRunwayCounts paved error", err)
+		return
+	}
+	unpaved, err := stringToRunwayCounts("total: 8,459\n2,438 to 3,047 m: 6\nunder 914 m: 7,140 (2013)")
+	if err != nil {
+		t.Error("stringToRunwayCounts unpaved error", err)
+		return
+	}
+	total, _ := paved.Get("total")
+	if total != 5054 {
+		t.Error("stringToRunwayCounts total", total)
+	}
+	merged := mergeRunwayCounts(paved, unpaved)
+	if len(merged) != 3 {
+		t.Error("mergeRunwayCounts rows", len(merged))
+		return
+	}
+	expected := []map[string]int{
+		map[string]int{"min_metres": 3047, "paved": 189, "unpaved": 0, "total": 189},
+		map[string]int{"min_metres": 2438, "paved": 235, "unpaved": 6, "total": 241},
+		map[string]int{"min_metres": 0, "paved": 1478, "unpaved": 7140, "total": 8618},
+	}
+	for i, row := range merged {
+		for key, value := range expected[i] {
+			v, _ := row.Get(key)
+			if v != value {
+				t.Error("mergeRunwayCounts", i, key, v)
+			}
+		}
+	}
+	// no unpaved runways
+	merged = mergeRunwayCounts(paved, orderedmap.New())
+	if len(merged) != 3 {
+		t.Error("mergeRunwayCounts paved only", len(merged))
+	}
+}
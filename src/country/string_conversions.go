@@ -7,13 +7,11 @@ import (
 	"strcase"
 	"strconv"
 	"strings"
-	"time"
 )
 
 var atLeastOneSpaceRe = regexp.MustCompile(`\s+`)
 var startsWithSpaceOrParenthesis = regexp.MustCompile(`^[\s\(\)]+`)
 var endsWithSpaceOrParenthesis = regexp.MustCompile(`[\s\(\)]+$`)
-var ageStructureSplitterRe = regexp.MustCompile(`[:%\(\)/]+`)
 var commaOrSemicolon = regexp.MustCompile(`[,;]+`)
 var keyEndingWithNumber = regexp.MustCompile(`\s+\(?[0-9]+\)?:`)
 var globalRankSpaces = regexp.MustCompile(`country comparison to the world:\s+`)
@@ -146,7 +144,7 @@ func stringToMap(s string) (*orderedmap.OrderedMap, error) {
 }
 
 func stringToMapOfNumbersWithUnits(s string) (*orderedmap.OrderedMap, error) {
-	s, date, hasDate := stringWithoutDate(s)
+	s, date, hasDate := stringWithoutDateDetail(s)
 	o, err := stringToMap(s)
 	if err != nil {
 		return o, StringToMapOfNumbersErr
@@ -183,13 +181,13 @@ func stringToMapOfNumbersWithUnits(s string) (*orderedmap.OrderedMap, error) {
 		o.Delete(key)
 	}
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
 
 func stringToMapOfNumbers(s string) (*orderedmap.OrderedMap, error) {
-	s, date, hasDate := stringWithoutDate(s)
+	s, date, hasDate := stringWithoutDateDetail(s)
 	o, err := stringToMap(s)
 	if err != nil {
 		return o, StringToMapOfNumbersErr
@@ -213,11 +211,12 @@ func stringToMapOfNumbers(s string) (*orderedmap.OrderedMap, error) {
 		}
 		// handle all other keys
 		valueStr, _ := o.Get(key)
-		valueNum, err := stringToNumber(valueStr.(string))
+		n, err := stringToNumericValue(valueStr.(string))
 		if err == StringIsNaErr {
 			keysToDelete = append(keysToDelete, key)
 		} else if err == nil {
-			o.Set(key, valueNum)
+			o.Set(key, n.lowerBound)
+			setNumericQualifiersForKey(o, key, n)
 		}
 	}
 	// remove invalid values
@@ -225,7 +224,7 @@ func stringToMapOfNumbers(s string) (*orderedmap.OrderedMap, error) {
 		o.Delete(key)
 	}
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
@@ -239,6 +238,14 @@ func stringToNumberWithUnits(s string) (*orderedmap.OrderedMap, error) {
 	if sTrimmed == "NA" {
 		return o, StringIsNaErr
 	}
+	n, err := stringToNumericValue(sTrimmed)
+	if err == nil && startsWith(sTrimmed, "NEGL") {
+		setNumericQualifiers(o, n)
+		o.Delete("units")
+		return o, nil
+	}
+	// parse the lower bound of ranges, the upper bound is added at the end
+	sTrimmed, _, _, _ = splitNumericQualifiers(sTrimmed)
 	// convert percentages into units
 	sTrimmed = strings.Replace(sTrimmed, "%", " %", -1)
 	// split number into bits
@@ -288,12 +295,14 @@ func stringToNumberWithUnits(s string) (*orderedmap.OrderedMap, error) {
 	o.Set("value", value)
 	if units != "" {
 		o.Set("units", units)
+		setCanonicalValue(o, value, units)
 	} else {
 		o.Delete("units")
 	}
 	if len(note) > 0 {
 		o.Set("note", note)
 	}
+	setNumericQualifiers(o, n)
 	return o, nil
 }
 
@@ -301,7 +310,8 @@ func stringToPercentage(s string) (*orderedmap.OrderedMap, error) {
 	o := orderedmap.New()
 	o.Set("value", 0.0)
 	o.Set("units", "")
-	s, date, hasDate := stringWithoutDate(s)
+	isEstimate := isEstimateStr(s)
+	s, date, hasDate := stringWithoutDateDetail(s)
 	sTrimmed := strings.TrimSpace(s)
 	// check string for invalid values
 	if sTrimmed == "NA" {
@@ -314,12 +324,16 @@ func stringToPercentage(s string) (*orderedmap.OrderedMap, error) {
 		return o, NoValueErr
 	}
 	// get the value
-	numStr := bits[0]
-	noCommas := strings.Replace(numStr, ",", "", -1)
-	value, err := strconv.ParseFloat(noCommas, 64)
+	numStr := strings.TrimSpace(bits[0])
+	if !startsWithNumber(strings.TrimPrefix(numStr, "~")) {
+		return o, StringToPercentageErr
+	}
+	n, err := stringToNumericValue(numStr)
 	if err != nil {
 		return o, StringToPercentageErr
 	}
+	n.isEstimate = n.isEstimate || isEstimate
+	value := n.lowerBound
 	// get the note
 	note := ""
 	if len(bits) > 1 {
@@ -333,11 +347,13 @@ func stringToPercentage(s string) (*orderedmap.OrderedMap, error) {
 	// bundle into a single map
 	o.Set("value", value)
 	o.Set("units", "%")
+	setCanonicalValue(o, value, "%")
+	setNumericQualifiers(o, n)
 	if len(note) > 0 {
 		o.Set("note", note)
 	}
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
@@ -352,7 +368,7 @@ func isUnitsStr(s string) bool {
 	isUnits = isUnits || s == "year"
 	isUnits = isUnits || s == "deaths_per_1000_live_births"
 	isUnits = isUnits || s == "%"
-	isUnits = isUnits || s == "USD"
+	isUnits = isUnits || isCurrencyCode(s)
 	isUnits = isUnits || s == "kWh"
 	isUnits = isUnits || s == "cu"
 	return isUnits
@@ -492,7 +508,7 @@ func stringToList(s string, lc listConditions) ([]string, error) {
 
 func stringToPercentageMap(s, datakey string) (*orderedmap.OrderedMap, error) {
 	// remove the date
-	s, date, hasDate := stringWithoutDate(s)
+	s, date, hasDate := stringWithoutDateDetail(s)
 	// convert entire string to a map
 	raw, err := stringToMap(s)
 	if err != nil {
@@ -526,51 +542,19 @@ func stringToPercentageMap(s, datakey string) (*orderedmap.OrderedMap, error) {
 	}
 	// if date is there, add it to the root map
 	if hasDate {
-		final.Set("date", date)
+		setDate(final, date)
 	}
 	return final, nil
 }
 
+// Returns s without the date and the date as a string, see factbookDate.
+// Use stringWithoutDateDetail to get the start, end and granularity.
 func stringWithoutDate(s string) (string, string, bool) {
-	_, ps := removeParenthesis(s)
-	dateStr := ""
-	sNoDate := s
-	for _, p := range ps {
-		pTidy := strings.Replace(p, " est.", "", -1)
-		pTidy = strings.Replace(pTidy, " es", "", -1)
-		bits := strings.Split(pTidy, " ")
-		if len(bits) == 1 {
-			if len(pTidy) == 7 && pTidy[4] == '/' {
-				pTidy = pTidy[0:4]
-			}
-			t, err := time.Parse("2006", pTidy)
-			if err != nil {
-				continue
-			}
-			dateStr = t.Format("2006")
-			sNoDate = strings.Replace(sNoDate, "("+p+")", "", -1)
-			break
-		} else if len(bits) == 2 {
-			t, err := time.Parse("January 2006", pTidy)
-			if err != nil {
-				continue
-			}
-			dateStr = t.Format("2006-01-02")
-			sNoDate = strings.Replace(sNoDate, "("+p+")", "", -1)
-			break
-		} else if len(bits) == 3 {
-			t, err := time.Parse("2 January 2006", pTidy)
-			if err != nil {
-				continue
-			}
-			dateStr = t.Format("2006-01-02")
-			sNoDate = strings.Replace(sNoDate, "("+p+")", "", -1)
-			break
-		}
+	sNoDate, d, hasDate := stringWithoutDateDetail(s)
+	if !hasDate {
+		return sNoDate, "", false
 	}
-	hasDate := dateStr != ""
-	sNoDate = strings.TrimSpace(sNoDate)
-	return sNoDate, dateStr, hasDate
+	return sNoDate, d.String(), true
 }
 
 func removeParenthesis(s string) (string, []string) {
@@ -601,94 +585,39 @@ func removeParenthesis(s string) (string, []string) {
 	return sNoPs, ps
 }
 
-func convertToFranceValue(s string) string {
-	if strings.Index(s, "metropolitan France: ") == -1 {
-		return s
-	}
-	o, err := stringToMap(s)
-	if err != nil {
-		return s
-	}
-	v, ok := o.Get("metropolitan_france")
-	if !ok {
-		return s
-	}
-	return v.(string)
-}
-
 func stringToNumberWithUnitsAndDate(s string) (*orderedmap.OrderedMap, error) {
-	s, date, hasDate := stringWithoutDate(s)
+	s, date, hasDate := stringWithoutDateDetail(s)
 	num, err := stringToNumberWithUnits(s)
 	if err != nil {
 		return num, err
 	}
 	if hasDate {
-		num.Set("date", date)
+		setDate(num, date)
 	}
 	return num, nil
 }
 
-func stringToNumber(s string) (float64, error) {
-	clean := s
-	clean = strings.TrimSpace(clean)
-	// remove range, use first value only
-	rangeBits := strings.Split(clean, "%")
-	if len(rangeBits) > 1 {
-		clean = rangeBits[0]
-	}
-	if strings.Index(clean, "-") > 0 {
-		rangeBits = strings.Split(clean, "-")
-		clean = rangeBits[0]
-	}
-	// clean it up
-	clean = strings.Replace(clean, "$", "", -1)
-	clean = strings.Replace(clean, "<", "", -1)
-	clean = strings.Replace(clean, ">", "", -1)
-	clean = strings.TrimSpace(clean)
-	bits := atLeastOneSpaceRe.Split(clean, -1)
-	if len(bits) == 0 {
-		return 0, NoValueErr
-	}
-	// split number into bits
-	// get the number component
-	var value float64
-	foundValue := false
-	magnitude := 1.0
-	for _, bit := range bits {
-		noCommas := strings.Replace(bit, ",", "", -1)
-		possibleValue, err := strconv.ParseFloat(noCommas, 64)
-		if err == nil && !foundValue {
-			value = possibleValue
-			foundValue = true
-		} else if bit == "thousand" {
-			magnitude = 1e3
-		} else if bit == "million" {
-			magnitude = 1e6
-		} else if bit == "billion" {
-			magnitude = 1e9
-		} else if bit == "trillion" {
-			magnitude = 1e12
-		}
-	}
-	if !foundValue {
-		return value, StringToNumberWithUnitsErr
-	}
-	// convert the number using the magnitude
-	value = value * magnitude
-	return value, nil
-}
-
-func stringToNumberWithGlobalRankAndDate(s, numberKey string) (*orderedmap.OrderedMap, error) {
+// Returns the number in the first line of s under numberKey, along with the
+// global rank and date. units is the units of the number, used for the
+// canonical value, or empty for numbers which count something, eg airports.
+func stringToNumberWithGlobalRankAndDate(s, numberKey, units string) (*orderedmap.OrderedMap, error) {
 	o := orderedmap.New()
 	// get date
-	s, date, hasDate := stringWithoutDate(s)
+	firstLineWithDate, _ := firstLine(s)
+	s, date, hasDate := stringWithoutDateDetail(s)
 	// get number
 	firstLine, otherLines := firstLine(s)
-	number, err := stringToNumber(firstLine)
+	n, err := stringToNumericValue(firstLine)
 	if err != nil {
 		return o, err
 	}
+	n.isEstimate = n.isEstimate || isEstimateStr(firstLineWithDate)
+	number := n.lowerBound
 	o.Set(numberKey, number)
+	if len(units) > 0 {
+		setCanonicalValue(o, number, units)
+	}
+	setNumericQualifiers(o, n)
 	// get global rank
 	lines := strings.Split(otherLines, "\n")
 	for _, line := range lines {
@@ -704,7 +633,7 @@ func stringToNumberWithGlobalRankAndDate(s, numberKey string) (*orderedmap.Order
 	}
 	// set date
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
@@ -723,16 +652,21 @@ func stringToListOfAnnualValues(s, units string) (*orderedmap.OrderedMap, error)
 		isNumberLine = isNumberLine || strings.Index(line, "%") > -1
 		isNumberLine = isNumberLine || startsWithNumber(line)
 		if isNumberLine {
-			line, date, hasDate := stringWithoutDate(line)
-			v, err := stringToNumber(line)
+			isEstimate := isEstimateStr(line)
+			line, date, hasDate := stringWithoutDateDetail(line)
+			n, err := stringToNumericValue(line)
 			if err != nil {
 				continue
 			}
+			n.isEstimate = n.isEstimate || isEstimate
+			v := n.lowerBound
 			annualValue := orderedmap.New()
 			annualValue.Set("value", v)
 			annualValue.Set("units", units)
+			setCanonicalValue(annualValue, v, units)
+			setNumericQualifiers(annualValue, n)
 			if hasDate {
-				annualValue.Set("date", date)
+				setDate(annualValue, date)
 			}
 			annualValues = append(annualValues, annualValue)
 		} else if startsWith(line, "note") {
@@ -753,11 +687,11 @@ func stringToListOfAnnualValues(s, units string) (*orderedmap.OrderedMap, error)
 			keys := m.Keys()
 			for _, k := range keys {
 				v, _ := m.Get(k)
-				vFloat, err := stringToNumber(v.(string))
+				n, err := stringToNumericValue(v.(string))
 				if err != nil {
 					continue
 				}
-				globalRank = vFloat
+				globalRank = n.lowerBound
 				hasGlobalRank = true
 			}
 		} else {
@@ -817,7 +751,7 @@ func stringToListOfAnnualValues(s, units string) (*orderedmap.OrderedMap, error)
 func stringToPercentageList(s, key string) (*orderedmap.OrderedMap, error) {
 	o := orderedmap.New()
 	// get date
-	s, date, hasDate := stringWithoutDate(s)
+	s, date, hasDate := stringWithoutDateDetail(s)
 	// get list
 	firstLine, otherLines := firstLine(s)
 	bits := splitIgnoringParenthesis(firstLine, ',')
@@ -825,8 +759,9 @@ func stringToPercentageList(s, key string) (*orderedmap.OrderedMap, error) {
 	for _, bit := range bits {
 		bitNoPs, ps := removeParenthesis(bit)
 		bitNoPs = strings.TrimSpace(bitNoPs)
-		bit = strings.Replace(bit, " - ", "-", -1)
-		percent := 0.0
+		// keep ranges in one part, eg 5 - 10%
+		bitNoPs = strings.Replace(bitNoPs, " - ", "-", -1)
+		var percent numericValue
 		hasPercent := false
 		var err error
 		name := ""
@@ -834,7 +769,7 @@ func stringToPercentageList(s, key string) (*orderedmap.OrderedMap, error) {
 		for _, part := range parts {
 			if startsWithNumber(part) {
 				percentStr := strings.Replace(part, "%", "", -1)
-				percent, err = stringToNumber(percentStr)
+				percent, err = stringToNumericValue(percentStr)
 				if err == nil {
 					hasPercent = true
 				} else {
@@ -850,7 +785,8 @@ func stringToPercentageList(s, key string) (*orderedmap.OrderedMap, error) {
 			m.Set("name", name)
 		}
 		if hasPercent {
-			m.Set("percent", percent)
+			m.Set("percent", percent.lowerBound)
+			setNumericQualifiers(m, percent)
 		}
 		// handle subsets
 		if len(ps) > 0 {
@@ -862,13 +798,13 @@ func stringToPercentageList(s, key string) (*orderedmap.OrderedMap, error) {
 					for _, breakdownBit := range breakdownBits {
 						breakdownBit = strings.TrimSpace(breakdownBit)
 						breakdownBit = strings.Replace(breakdownBit, " - ", "-", -1)
-						bPercent := 0.0
+						var bPercent numericValue
 						bHasPercent := false
 						bName := ""
 						bParts := strings.Split(breakdownBit, " ")
 						for _, bPart := range bParts {
 							if startsWithNumber(bPart) {
-								bPercent, err = stringToNumber(bPart)
+								bPercent, err = stringToNumericValue(strings.Replace(bPart, "%", "", -1))
 								if err == nil {
 									bHasPercent = true
 								} else {
@@ -884,7 +820,8 @@ func stringToPercentageList(s, key string) (*orderedmap.OrderedMap, error) {
 							bm.Set("name", bName)
 						}
 						if bHasPercent {
-							bm.Set("percent", bPercent)
+							bm.Set("percent", bPercent.lowerBound)
+							setNumericQualifiers(bm, bPercent)
 						}
 						breakdown = append(breakdown, bm)
 					}
@@ -920,7 +857,7 @@ func stringToPercentageList(s, key string) (*orderedmap.OrderedMap, error) {
 	}
 	// set date
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	if len(o.Keys()) == 0 {
 		return o, NoValueErr
@@ -928,34 +865,6 @@ func stringToPercentageList(s, key string) (*orderedmap.OrderedMap, error) {
 	return o, nil
 }
 
-func stringToAgeStructureMap(s string) (*orderedmap.OrderedMap, error) {
-	o := orderedmap.New()
-	bits := ageStructureSplitterRe.Split(s, -1)
-	if len(bits) == 6 {
-		// percent
-		percentStr := strings.TrimSpace(bits[1])
-		percent, err := strconv.ParseFloat(percentStr, 64)
-		if err == nil {
-			o.Set("percent", percent)
-		}
-		// male
-		maleStr := strings.Replace(bits[3], "male ", "", -1)
-		maleStr = strings.Replace(maleStr, ",", "", -1)
-		male, err := strconv.Atoi(strings.TrimSpace(maleStr))
-		if err == nil {
-			o.Set("males", male)
-		}
-		// female
-		femaleStr := strings.Replace(bits[4], "female ", "", -1)
-		femaleStr = strings.Replace(femaleStr, ",", "", -1)
-		female, err := strconv.Atoi(strings.TrimSpace(femaleStr))
-		if err == nil {
-			o.Set("females", female)
-		}
-	}
-	return o, nil
-}
-
 func startsWithNumber(s string) bool {
 	s = strings.TrimSpace(s)
 	if len(s) > 0 && s[0] == '<' {
@@ -992,7 +901,7 @@ func stringToImprovedUnimprovedList(s string) (*orderedmap.OrderedMap, error) {
 	improved := orderedmap.New()
 	unimproved := orderedmap.New()
 	// get date
-	s, date, hasDate := stringWithoutDate(s)
+	s, date, hasDate := stringWithoutDateDetail(s)
 	// get lines
 	lines := strings.Split(s, "\n")
 	topKey := ""
@@ -1014,13 +923,14 @@ func stringToImprovedUnimprovedList(s string) (*orderedmap.OrderedMap, error) {
 		}
 		secondKey := bits[0]
 		// get percentage
-		p, err := stringToNumber(bits[1])
+		p, err := stringToNumericValue(bits[1])
 		if err != nil {
 			continue
 		}
 		percentage := orderedmap.New()
-		percentage.Set("value", p)
+		percentage.Set("value", p.lowerBound)
 		percentage.Set("units", "percent of population")
+		setNumericQualifiers(percentage, p)
 		// set values
 		if topKey == "improved" {
 			improved.Set(secondKey, percentage)
@@ -1045,7 +955,7 @@ func stringToImprovedUnimprovedList(s string) (*orderedmap.OrderedMap, error) {
 	}
 	// set date
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	return o, nil
 }
@@ -1062,7 +972,7 @@ var singulars = map[string]string{
 	"cities":                               "city",
 	"cities with provincial status":        "city with provincial status",
 	"communes":                             "commune",
-	"counties":                             "country",
+	"counties":                             "county",
 	"departments":                          "department",
 	"dependencies":                         "dependency",
 	"districts":                            "district",
@@ -1070,7 +980,7 @@ var singulars = map[string]string{
 	"emirates":                             "emirate",
 	"ethnically based states":              "ethnically based state",
 	"first-order administrative divisions": "first-order administrative division",
-	"governorates":                         "governate",
+	"governorates":                         "governorate",
 	"indigenous territories":               "indigenous territory",
 	"islands":                              "island",
 	"island councils":                      "island council",
@@ -1181,28 +1091,29 @@ func stringToImportExportPartnerList(s string) (*orderedmap.OrderedMap, error) {
 	s = strings.Replace(s, "% (", "%, (", -1)
 	o := orderedmap.New()
 	ps := []*orderedmap.OrderedMap{}
-	s, date, hasDate := stringWithoutDate(s)
+	s, date, hasDate := stringWithoutDateDetail(s)
 	partnerStrs := strings.Split(s, ", ")
 	for _, partnerStr := range partnerStrs {
 		bits := strings.Split(partnerStr, " ")
 		if len(bits) < 2 {
 			continue
 		}
-		percent, err := stringToNumber(bits[len(bits)-1])
+		percent, err := stringToNumericValue(bits[len(bits)-1])
 		if err != nil {
 			continue
 		}
 		name := strings.Join(bits[0:len(bits)-1], " ")
 		p := orderedmap.New()
 		p.Set("name", name)
-		p.Set("percent", percent)
+		p.Set("percent", percent.lowerBound)
+		setNumericQualifiers(p, percent)
 		ps = append(ps, p)
 	}
 	if len(ps) > 0 {
 		o.Set("by_country", ps)
 	}
 	if hasDate {
-		o.Set("date", date)
+		setDate(o, date)
 	}
 	keys := o.Keys()
 	if len(keys) == 0 {
@@ -1218,25 +1129,29 @@ func stringToRailLengthMap(s string) (*orderedmap.OrderedMap, error) {
 	bits := strings.Split(s, " ")
 	if len(bits) > 1 {
 		// length
-		length, err := stringToNumber(bits[0])
+		n, err := stringToNumericValue(bits[0])
 		if err != nil {
 			return o, err
 		}
+		length := n.lowerBound
 		o.Set("length", length)
+		setNumericQualifiersForKey(o, "length", n)
 		// electrified
 		for _, p := range ps {
 			pBits := strings.Split(p, " ")
 			if len(pBits) > 2 && pBits[2] == "electrified" {
-				length, err := stringToNumber(pBits[0])
+				electrified, err := stringToNumericValue(pBits[0])
 				if err != nil {
 					continue
 				}
-				o.Set("electrified", length)
+				o.Set("electrified", electrified.lowerBound)
+				setNumericQualifiersForKey(o, "electrified", electrified)
 			}
 		}
 		// units
 		units := bits[1]
 		o.Set("units", units)
+		setCanonicalValue(o, length, units)
 	}
 	if len(bits) > 3 {
 		// guage size
@@ -1254,14 +1169,15 @@ func stringToListOfCounts(s, nameKey string) ([]*orderedmap.OrderedMap, error) {
 		countStrBits := strings.Split(countStr, " ")
 		if len(countStrBits) > 1 {
 			cStr := countStrBits[len(countStrBits)-1]
-			count, err := stringToNumber(cStr)
+			count, err := stringToNumericValue(cStr)
 			if err != nil {
 				return maps, err
 			}
 			name := strings.Join(countStrBits[0:len(countStrBits)-1], " ")
 			m := orderedmap.New()
 			m.Set(nameKey, name)
-			m.Set("count", count)
+			m.Set("count", count.lowerBound)
+			setNumericQualifiers(m, count)
 			maps = append(maps, m)
 		}
 	}
@@ -1272,12 +1188,13 @@ func stringToListOfCounts(s, nameKey string) ([]*orderedmap.OrderedMap, error) {
 func stringToListOfCountsWithTotal(s, nameKey string) (*orderedmap.OrderedMap, error) {
 	o := orderedmap.New()
 	s, ps := removeParenthesis(s)
-	total, err := stringToNumber(s)
+	total, err := stringToNumericValue(s)
 	if err != nil {
 		return o, err
 	}
 	// total
-	o.Set("total", total)
+	o.Set("total", total.lowerBound)
+	setNumericQualifiers(o, total)
 	// others
 	if len(ps) == 1 {
 		m, err := stringToListOfCounts(ps[0], nameKey)
@@ -1294,15 +1211,16 @@ func stringToListWithItemNotes(s, itemKey, noteKey string, noteMayBeNumber bool)
 	o := []*orderedmap.OrderedMap{}
 	bits := strings.Split(s, ", ")
 	for _, bit := range bits {
-		bit, date, hasDate := stringWithoutDate(bit)
+		bit, date, hasDate := stringWithoutDateDetail(bit)
 		bit, ps := removeParenthesis(bit)
 		item := orderedmap.New()
 		// name
 		name := strings.TrimSpace(bit)
 		// try converting to number if possible
-		nameNum, err := stringToNumber(name)
+		nameNum, err := stringToNumericValue(name)
 		if err == nil {
-			item.Set(itemKey, nameNum)
+			item.Set(itemKey, nameNum.lowerBound)
+			setNumericQualifiersForKey(item, itemKey, nameNum)
 		} else {
 			item.Set(itemKey, name)
 		}
@@ -1310,16 +1228,17 @@ func stringToListWithItemNotes(s, itemKey, noteKey string, noteMayBeNumber bool)
 		note := strings.Join(ps, "; ")
 		if len(note) > 0 {
 			// try converting to number if possible
-			noteNum, err := stringToNumber(note)
+			noteNum, err := stringToNumericValue(note)
 			if err == nil && noteMayBeNumber {
-				item.Set(noteKey, noteNum)
+				item.Set(noteKey, noteNum.lowerBound)
+				setNumericQualifiersForKey(item, noteKey, noteNum)
 			} else {
 				item.Set(noteKey, note)
 			}
 		}
 		// date
 		if hasDate {
-			item.Set("date", date)
+			setDate(item, date)
 		}
 		o = append(o, item)
 	}
@@ -1328,3 +1247,124 @@ func stringToListWithItemNotes(s, itemKey, noteKey string, noteMayBeNumber bool)
 	}
 	return o, nil
 }
+
+// eg euros (EUR) per US dollar -
+// 0.885 (2017 est.)
+// 0.903 (2016 est.)
+// {
+//   "currency": "euros",
+//   "currency_code": "EUR",
+//   "annual_values": [
+//     {
+//       "value": 0.885,
+//       "units": "EUR per USD",
+//       "date": "2017"
+//     },
+//     ...
+//   ]
+// }
+//
+// pages listing several currencies, eg United States, are saved in key
+// 'by_currency'
+func stringToExchangeRates(s string) (*orderedmap.OrderedMap, error) {
+	// group lines by the currency they belong to
+	groups := []string{}
+	lines := strings.Split(s, "\n")
+	for _, line := range lines {
+		startsCurrency := strings.Index(line, "per US dollar") > -1
+		if startsCurrency || len(groups) == 0 {
+			groups = append(groups, line)
+		} else {
+			groups[len(groups)-1] = groups[len(groups)-1] + "\n" + line
+		}
+	}
+	rates := []*orderedmap.OrderedMap{}
+	for _, group := range groups {
+		rate, err := stringToExchangeRate(group)
+		if err != nil {
+			continue
+		}
+		rates = append(rates, rate)
+	}
+	if len(rates) == 0 {
+		return orderedmap.New(), NoValueErr
+	}
+	if len(rates) == 1 {
+		return rates[0], nil
+	}
+	o := orderedmap.New()
+	o.Set("by_currency", rates)
+	return o, nil
+}
+
+// Sets the units of each rate for a currency name without a code to the
+// code in use in the year of the rate, since some currencies were
+// redenominated under the same name. Returns the code of the latest rate.
+func setExchangeRateCodes(values *orderedmap.OrderedMap, name string) (string, bool) {
+	latestCode := ""
+	latestYear := -1
+	valuesInterface, _ := values.Get("annual_values")
+	annualValues, _ := valuesInterface.([]*orderedmap.OrderedMap)
+	for _, annualValue := range annualValues {
+		year := 0
+		date, _ := annualValue.Get("date")
+		dateStr, _ := date.(string)
+		if len(dateStr) >= 4 {
+			year, _ = strconv.Atoi(dateStr[0:4])
+		}
+		code, exists := currencyCodeForName(name, year)
+		if !exists {
+			return "", false
+		}
+		units := code + " per USD"
+		value, _ := annualValue.Get("value")
+		annualValue.Set("units", units)
+		setCanonicalValue(annualValue, value.(float64), units)
+		if year > latestYear {
+			latestYear = year
+			latestCode = code
+		}
+	}
+	if latestYear == -1 {
+		return currencyCodeForName(name, 0)
+	}
+	return latestCode, true
+}
+
+func stringToExchangeRate(s string) (*orderedmap.OrderedMap, error) {
+	perUsdIndex := strings.Index(s, "per US dollar")
+	if perUsdIndex == -1 {
+		// see Ecuador, the US dollar is used
+		return stringToListOfAnnualValues(s, "USD")
+	}
+	currencyStr := s[0:perUsdIndex]
+	valuesStr := s[perUsdIndex+len("per US dollar") : len(s)]
+	name, code, hasCode := currencyFromString(currencyStr)
+	// values may follow on the same line, eg
+	// euros per US dollar - 0.7489 (2011), 0.755 (2010)
+	valuesStr = strings.TrimLeft(valuesStr, " -:")
+	valuesStr = strings.Replace(valuesStr, "), ", ")\n", -1)
+	units := name + " per USD"
+	if hasCode {
+		units = code + " per USD"
+	}
+	values, err := stringToListOfAnnualValues(strings.TrimSpace(valuesStr), units)
+	if err != nil && len(name) == 0 {
+		return values, err
+	}
+	if !hasCode {
+		code, hasCode = setExchangeRateCodes(values, name)
+	}
+	o := orderedmap.New()
+	if len(name) > 0 {
+		o.Set("currency", name)
+	}
+	if hasCode {
+		o.Set("currency_code", code)
+	}
+	for _, key := range values.Keys() {
+		v, _ := values.Get(key)
+		o.Set(key, v)
+	}
+	return o, nil
+}
@@ -530,6 +530,7 @@ var stringToPercentageListCases = []StringToPercentageListCase{
 		expectedKeys: []string{
 			"religion",
 			"date",
+			"date_detail",
 		},
 		expectedNames: []string{
 			"Muslim",
@@ -686,3 +687,114 @@ func TestStringToPercentageList(t *testing.T) {
 		}
 	}
 }
+
+type StringToExchangeRatesCase struct {
+	s                    string
+	expectedCurrency     string
+	expectedCurrencyCode string
+	expectedUnits        []string
+	expectedValues       []float64
+	expectedDates        []string
+}
+
+var stringToExchangeRatesCases = []StringToExchangeRatesCase{
+	// code in parenthesis
+	StringToExchangeRatesCase{
+		s:                    "euros (EUR) per US dollar -\n0.885 (2017 est.)\n0.903 (2016 est.)",
+		expectedCurrency:     "euros",
+		expectedCurrencyCode: "EUR",
+		expectedUnits:        []string{"EUR per USD", "EUR per USD"},
+		expectedValues:       []float64{0.885, 0.903},
+		expectedDates:        []string{"2017", "2016"},
+	},
+	// values on the same line, code from the name
+	StringToExchangeRatesCase{
+		s:                    "Japanese yen per US dollar - 79.81 (2011), 87.78 (2010)",
+		expectedCurrency:     "Japanese yen",
+		expectedCurrencyCode: "JPY",
+		expectedUnits:        []string{"JPY per USD", "JPY per USD"},
+		expectedValues:       []float64{79.81, 87.78},
+		expectedDates:        []string{"2011", "2010"},
+	},
+	// the code from the name depends on the year, see Venezuela
+	StringToExchangeRatesCase{
+		s:                    "Venezuelan bolivars per US dollar -\n9.975 (2017 est.)\n2.1 (2007 est.)",
+		expectedCurrency:     "Venezuelan bolivars",
+		expectedCurrencyCode: "VEF",
+		expectedUnits:        []string{"VEF per USD", "VEB per USD"},
+		expectedValues:       []float64{9.975, 2.1},
+		expectedDates:        []string{"2017", "2007"},
+	},
+	// unknown currency name
+	StringToExchangeRatesCase{
+		s:                    "imaginary crowns per US dollar -\n2.5 (2017 est.)",
+		expectedCurrency:     "imaginary crowns",
+		expectedCurrencyCode: "",
+		expectedUnits:        []string{"imaginary crowns per USD"},
+		expectedValues:       []float64{2.5},
+		expectedDates:        []string{"2017"},
+	},
+}
+
+func TestStringToExchangeRates(t *testing.T) {
+	for testIndex, c := range stringToExchangeRatesCases {
+		o, err := stringToExchangeRates(c.s)
+		if err != nil {
+			t.Error("stringToExchangeRates error, testIndex: ", testIndex, err)
+			continue
+		}
+		currency, _ := o.Get("currency")
+		if currency != c.expectedCurrency {
+			t.Error("stringToExchangeRates currency, testIndex: ", testIndex, currency)
+		}
+		code, hasCode := o.Get("currency_code")
+		if c.expectedCurrencyCode == "" && hasCode {
+			t.Error("stringToExchangeRates unexpected currency_code, testIndex: ", testIndex, code)
+		}
+		if c.expectedCurrencyCode != "" && code != c.expectedCurrencyCode {
+			t.Error("stringToExchangeRates currency_code, testIndex: ", testIndex, code)
+		}
+		valuesInterface, _ := o.Get("annual_values")
+		values := valuesInterface.([]*orderedmap.OrderedMap)
+		if len(values) != len(c.expectedValues) {
+			t.Error("stringToExchangeRates values length, testIndex: ", testIndex)
+			continue
+		}
+		for i, v := range values {
+			value, _ := v.Get("value")
+			if value.(float64) != c.expectedValues[i] {
+				t.Error("stringToExchangeRates value, testIndex: ", testIndex, value)
+			}
+			units, _ := v.Get("units")
+			if units != c.expectedUnits[i] {
+				t.Error("stringToExchangeRates units, testIndex: ", testIndex, units)
+			}
+			date, _ := v.Get("date")
+			if date != c.expectedDates[i] {
+				t.Error("stringToExchangeRates date, testIndex: ", testIndex, date)
+			}
+		}
+	}
+}
+
+func TestStringToExchangeRatesByCurrency(t *testing.T) {
+	s := "British pounds per US dollar: 0.7836 (2017 est.)\nCanadian dollars per US dollar: 1.298 (2017 est.)"
+	o, err := stringToExchangeRates(s)
+	if err != nil {
+		t.Error("stringToExchangeRates by currency error", err)
+	}
+	ratesInterface, exists := o.Get("by_currency")
+	if !exists {
+		t.Error("stringToExchangeRates by currency missing")
+		return
+	}
+	rates := ratesInterface.([]*orderedmap.OrderedMap)
+	if len(rates) != 2 {
+		t.Error("stringToExchangeRates by currency length", len(rates))
+		return
+	}
+	code, _ := rates[1].Get("currency_code")
+	if code != "CAD" {
+		t.Error("stringToExchangeRates by currency code", code)
+	}
+}
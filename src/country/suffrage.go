@@ -0,0 +1,222 @@
+package country
+
+import (
+	"orderedmap"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Age patterns in the order they are checked, each with the index of the
+// minimum and maximum age in the match. An index of 0 means no age.
+// Ages above a number, eg over 70, start at the next year, and ages below a
+// number, eg less than 80, end at the year before.
+type suffrageAgePattern struct {
+	re        *regexp.Regexp
+	min       int
+	max       int
+	exclusive bool
+}
+
+var suffrageAgePatterns = []suffrageAgePattern{
+	// eg 18-70 years of age, 16-18
+	suffrageAgePattern{regexp.MustCompile(`\b([0-9]{2})\s*-\s*([0-9]{2})\b`), 1, 2, false},
+	// eg between 16 and 18 years of age, see Brazil
+	suffrageAgePattern{regexp.MustCompile(`\bbetween ([0-9]{2}) and ([0-9]{2})\b`), 1, 2, false},
+	// eg 18 to 70 years of age
+	suffrageAgePattern{regexp.MustCompile(`\b([0-9]{2}) to ([0-9]{2})\b`), 1, 2, false},
+	// eg less than 80 years old, see Holy See
+	suffrageAgePattern{regexp.MustCompile(`\b(?:less than|under) ([0-9]{2})\b`), 0, 1, true},
+	// eg 18 years of age, at age 21
+	suffrageAgePattern{regexp.MustCompile(`\b([0-9]{2}) years\b`), 1, 0, false},
+	suffrageAgePattern{regexp.MustCompile(`\bat age ([0-9]{2})\b`), 1, 0, false},
+	// eg over 70, see Brazil
+	suffrageAgePattern{regexp.MustCompile(`\bover ([0-9]{2})\b`), 1, 0, true},
+}
+
+var suffrageNoteRe = regexp.MustCompile(`(?:^|;\s*|\n)note(?:s)?\s*(?:-|:)\s*`)
+
+var nonCompulsoryRe = regexp.MustCompile(`\bnon-?compulsory\b`)
+
+// suffrageGroup is the voting rule for one group of voters, eg voters
+// over 70 for whom voting is voluntary.
+type suffrageGroup struct {
+	minAge     int
+	maxAge     int
+	hasAge     bool
+	hasMaxAge  bool
+	universal  bool
+	compulsory bool
+	voluntary  bool
+	text       []string
+}
+
+// suffrageAgeRange is one age clause, eg between 16 and 18 or over 70
+type suffrageAgeRange struct {
+	start     int
+	end       int
+	minAge    int
+	maxAge    int
+	hasAge    bool
+	hasMaxAge bool
+}
+
+// Returns every age clause in s in the order they appear. Where patterns
+// overlap the earlier pattern is used, eg 18-70 rather than 70 years.
+func suffrageAges(s string) []suffrageAgeRange {
+	ranges := []suffrageAgeRange{}
+	for _, p := range suffrageAgePatterns {
+		for _, m := range p.re.FindAllStringSubmatchIndex(s, -1) {
+			overlaps := false
+			for _, r := range ranges {
+				if m[0] < r.end && r.start < m[1] {
+					overlaps = true
+				}
+			}
+			if overlaps {
+				continue
+			}
+			r := suffrageAgeRange{
+				start:     m[0],
+				end:       m[1],
+				hasAge:    p.min > 0,
+				hasMaxAge: p.max > 0,
+			}
+			if p.min > 0 {
+				r.minAge, _ = strconv.Atoi(s[m[2*p.min]:m[2*p.min+1]])
+			}
+			if p.max > 0 {
+				r.maxAge, _ = strconv.Atoi(s[m[2*p.max]:m[2*p.max+1]])
+			}
+			if p.exclusive && p.min > 0 {
+				r.minAge = r.minAge + 1
+			}
+			if p.exclusive && p.max > 0 {
+				r.maxAge = r.maxAge - 1
+			}
+			ranges = append(ranges, r)
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start < ranges[j].start
+	})
+	return ranges
+}
+
+// Returns the first compulsory group, or the first group which is not
+// voluntary, or the first group
+func generalSuffrageGroup(groups []*suffrageGroup) *suffrageGroup {
+	for _, g := range groups {
+		if g.compulsory {
+			return g
+		}
+	}
+	for _, g := range groups {
+		if !g.voluntary {
+			return g
+		}
+	}
+	return groups[0]
+}
+
+func (g *suffrageGroup) addText(s string) {
+	lower := strings.ToLower(s)
+	if strings.Index(lower, "universal") > -1 {
+		g.universal = true
+	}
+	if strings.Index(lower, "compulsory") > -1 && !nonCompulsoryRe.MatchString(lower) {
+		g.compulsory = true
+	}
+	if strings.Index(lower, "voluntary") > -1 || strings.Index(lower, "optional") > -1 || nonCompulsoryRe.MatchString(lower) {
+		g.voluntary = true
+	}
+	g.text = append(g.text, s)
+}
+
+func (g suffrageGroup) toMap() *orderedmap.OrderedMap {
+	o := orderedmap.New()
+	if g.hasAge {
+		o.Set("min_age", g.minAge)
+	}
+	if g.hasMaxAge {
+		o.Set("max_age", g.maxAge)
+	}
+	o.Set("universal", g.universal)
+	o.Set("compulsory", g.compulsory)
+	o.Set("voluntary", g.voluntary)
+	o.Set("text", strings.Join(g.text, "; "))
+	return o
+}
+
+// Splits suffrage into groups, where each group starts with an age, eg
+// "18-70 years of age; universal and compulsory; 16-17 years of age - optional"
+// is two groups. Each age in a part is its own group, eg "voluntary between 16
+// and 18 years of age and over 70" is two voluntary groups, see Brazil.
+// The general rule is the first compulsory group, or the first group which is
+// not voluntary, or the first group.
+// Text following the first note is not part of any group.
+func stringToSuffrage(value string) (*orderedmap.OrderedMap, error) {
+	o := orderedmap.New()
+	value = strings.TrimSpace(value)
+	notes := []string{}
+	bits := suffrageNoteRe.Split(value, -1)
+	if len(bits) > 1 {
+		value = bits[0]
+		for _, note := range bits[1:len(bits)] {
+			note = strings.TrimSpace(note)
+			if len(note) > 0 {
+				notes = append(notes, note)
+			}
+		}
+	}
+	groups := []*suffrageGroup{}
+	for _, part := range strings.Split(value, ";") {
+		part = strings.TrimSpace(part)
+		if len(part) == 0 {
+			continue
+		}
+		ages := suffrageAges(part)
+		if len(ages) == 0 {
+			if len(groups) == 0 {
+				groups = append(groups, &suffrageGroup{})
+			}
+			groups[len(groups)-1].addText(part)
+			continue
+		}
+		for _, r := range ages {
+			g := &suffrageGroup{
+				minAge:    r.minAge,
+				maxAge:    r.maxAge,
+				hasAge:    r.hasAge,
+				hasMaxAge: r.hasMaxAge,
+			}
+			g.addText(part)
+			groups = append(groups, g)
+		}
+	}
+	if len(groups) == 0 && len(notes) == 0 {
+		return o, NoValueErr
+	}
+	if len(groups) > 0 {
+		g := generalSuffrageGroup(groups)
+		if g.hasAge {
+			o.Set("age", g.minAge)
+			o.Set("min_age", g.minAge)
+		}
+		if g.hasMaxAge {
+			o.Set("max_age", g.maxAge)
+		}
+		o.Set("universal", g.universal)
+		o.Set("compulsory", g.compulsory)
+	}
+	groupMaps := []*orderedmap.OrderedMap{}
+	for _, g := range groups {
+		groupMaps = append(groupMaps, g.toMap())
+	}
+	o.Set("groups", groupMaps)
+	if len(notes) > 0 {
+		o.Set("notes", notes)
+	}
+	return o, nil
+}
@@ -0,0 +1,123 @@
+package country
+
+import (
+	"orderedmap"
+	"testing"
+)
+
+type SuffrageCase struct {
+	s                  string
+	expectedMinAge     interface{}
+	expectedMaxAge     interface{}
+	expectedUniversal  bool
+	expectedCompulsory bool
+	expectedGroups     int
+	expectedNotes      int
+}
+
+var suffrageCases = []SuffrageCase{
+	SuffrageCase{
+		s:                 "18 years of age; universal",
+		expectedMinAge:    18,
+		expectedUniversal: true,
+		expectedGroups:    1,
+	},
+	// see Argentina
+	SuffrageCase{
+		s:                  "18-70 years of age; universal and compulsory; 16-17 years of age - optional for national elections",
+		expectedMinAge:     18,
+		expectedMaxAge:     70,
+		expectedUniversal:  true,
+		expectedCompulsory: true,
+		expectedGroups:     2,
+	},
+	// see Brazil
+	SuffrageCase{
+		s:                  "voluntary between 16 and 18 years of age and over 70; compulsory 18 to 70 years of age; note - military conscripts by law cannot vote",
+		expectedMinAge:     18,
+		expectedMaxAge:     70,
+		expectedCompulsory: true,
+		expectedGroups:     3,
+		expectedNotes:      1,
+	},
+	SuffrageCase{
+		s:                 "21 years of age; universal; note - a second note",
+		expectedMinAge:    21,
+		expectedUniversal: true,
+		expectedGroups:    1,
+		expectedNotes:     1,
+	},
+	// see Holy See
+	SuffrageCase{
+		s:              "limited to cardinals less than 80 years old",
+		expectedMaxAge: 79,
+		expectedGroups: 1,
+	},
+}
+
+func TestSuffrage(t *testing.T) {
+	for testIndex, c := range suffrageCases {
+		o, err := stringToSuffrage(c.s)
+		if err != nil {
+			t.Error("suffrage error, testIndex: ", testIndex, err)
+			continue
+		}
+		minAge, _ := o.Get("min_age")
+		if minAge != c.expectedMinAge {
+			t.Error("suffrage min_age, testIndex: ", testIndex, minAge)
+		}
+		maxAge, _ := o.Get("max_age")
+		if maxAge != c.expectedMaxAge {
+			t.Error("suffrage max_age, testIndex: ", testIndex, maxAge)
+		}
+		universal, _ := o.Get("universal")
+		if universal != c.expectedUniversal {
+			t.Error("suffrage universal, testIndex: ", testIndex, universal)
+		}
+		compulsory, _ := o.Get("compulsory")
+		if compulsory != c.expectedCompulsory {
+			t.Error("suffrage compulsory, testIndex: ", testIndex, compulsory)
+		}
+		groups, _ := o.Get("groups")
+		if len(groups.([]*orderedmap.OrderedMap)) != c.expectedGroups {
+			t.Error("suffrage groups, testIndex: ", testIndex, groups)
+		}
+		notes, exists := o.Get("notes")
+		if c.expectedNotes == 0 && exists {
+			t.Error("suffrage unexpected notes, testIndex: ", testIndex, notes)
+		}
+		if c.expectedNotes > 0 && (!exists || len(notes.([]string)) != c.expectedNotes) {
+			t.Error("suffrage notes, testIndex: ", testIndex, notes)
+		}
+	}
+}
+
+func TestSuffrageGroups(t *testing.T) {
+	// see Brazil, each age is its own group
+	o, err := stringToSuffrage("voluntary between 16 and 18 years of age and over 70; compulsory 18 to 70 years of age")
+	if err != nil {
+		t.Error("suffrage groups error", err)
+		return
+	}
+	groups, _ := o.Get("groups")
+	expected := []struct {
+		minAge     interface{}
+		maxAge     interface{}
+		compulsory bool
+		voluntary  bool
+	}{
+		{16, 18, false, true},
+		{71, nil, false, true},
+		{18, 70, true, false},
+	}
+	for i, g := range groups.([]*orderedmap.OrderedMap) {
+		minAge, _ := g.Get("min_age")
+		maxAge, _ := g.Get("max_age")
+		compulsory, _ := g.Get("compulsory")
+		voluntary, _ := g.Get("voluntary")
+		e := expected[i]
+		if minAge != e.minAge || maxAge != e.maxAge || compulsory != e.compulsory || voluntary != e.voluntary {
+			t.Error("suffrage group", i, minAge, maxAge, compulsory, voluntary)
+		}
+	}
+}
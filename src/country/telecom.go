@@ -0,0 +1,341 @@
+package country
+
+import (
+	"orderedmap"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The telephone system keys which are parsed for cables, earth stations and
+// teledensity. Other keys are left as text.
+var telephoneSystemKeys = []string{"general_assessment", "domestic", "international"}
+
+// eg "submarine cables: SAFE, SAT-3/WASC", "submarine cable systems - X and Y"
+var submarineCableListRe = regexp.MustCompile(`(?i)^submarine cables?(?: systems?)?\s*(?::|-)\s*(.+)$`)
+
+// eg "landing point for the SAT-3/WASC and WACS fiber-optic submarine cables"
+// where the names follow the last preposition
+var submarineCableNamesRe = regexp.MustCompile(`(?i)^(.*?)\s+(?:(?:fib(?:er|re)[- ]optic|optical fib(?:er|re))\s+)?submarine cables?\b`)
+var cableNameStartRe = regexp.MustCompile(`(?i)^.*\b(?:for|to|by|via|of|on|including|with)\s+`)
+
+var satelliteEarthStationRe = regexp.MustCompile(`(?i)satellite earth stations?`)
+var earthStationTotalRe = regexp.MustCompile(`(?i)satellite earth stations?\s*-\s*([0-9]+)\b`)
+var earthStationCountRe = regexp.MustCompile(`(?i)\b([0-9]+)\s+(?:[a-z]+\s+){0,2}satellite earth stations?`)
+
+// Satellite operators named with earth station counts, eg 61 Intelsat
+var satelliteOperators = []string{
+	"Intelsat",
+	"Inmarsat",
+	"Intersputnik",
+	"Eutelsat",
+	"Arabsat",
+	"PanAmSat",
+	"Thuraya",
+	"Globalstar",
+	"Iridium",
+	"Orbita",
+	"Statsionar",
+	"Molniya",
+	"Gorizont",
+	"AsiaSat",
+	"Measat",
+	"Palapa",
+	"Turksat",
+	"Nilesat",
+	"Hispasat",
+	"Astra",
+	"Optus",
+	"Apstar",
+	"Satmex",
+	"Anik",
+	"Afristar",
+	"New Skies",
+	"SES",
+}
+
+var satelliteOperatorRe = regexp.MustCompile(`(?i)\b([0-9]+)\s+(` + strings.Join(satelliteOperators, "|") + `)\b(\s*\([^)]*\))?`)
+
+// eg "fixed-line teledensity about 1 per 100 persons", "combined fixed-line
+// and mobile-cellular teledensity about 45 telephones per 100 persons"
+var teledensityRe = regexp.MustCompile(`(?i)teledensity[^;0-9]{0,40}?([0-9]+(?:\.[0-9]+)?)\s*(?:[a-z-]+\s+){0,2}per 100\b`)
+
+// Number words used for station counts, eg "a dozen private radio stations"
+var stationNumberWords = map[string]int{
+	"one":          1,
+	"two":          2,
+	"three":        3,
+	"four":         4,
+	"five":         5,
+	"six":          6,
+	"seven":        7,
+	"eight":        8,
+	"nine":         9,
+	"ten":          10,
+	"eleven":       11,
+	"twelve":       12,
+	"a dozen":      12,
+	"a half-dozen": 6,
+	"half a dozen": 6,
+	"a half dozen": 6,
+}
+
+// eg "5 privately owned TV stations", "about 20 private radio stations",
+// "2 state-owned TV and radio stations"
+var broadcastStationRe = regexp.MustCompile(`(?i)(?:\b(about|approximately|an estimated|estimated|roughly|some|more than|over|nearly|almost|at least)\s+)?\b([0-9][0-9,]*|a half-dozen|half a dozen|a half dozen|a dozen|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve)\s+((?:[a-z-]+\s+){0,3}?)(tv|television|radio)(?:\s+and\s+(tv|television|radio))?\s+(stations?|channels?|networks?|broadcasters?)\b`)
+var stateOwnershipRe = regexp.MustCompile(`(?i)\b(?:state|state-owned|state-run|state-controlled|government|government-owned|government-run|public|publicly owned|public service)\b`)
+var privateOwnershipRe = regexp.MustCompile(`(?i)\b(?:private|privately owned|privately-owned|commercial|independent|non-state)\b`)
+
+// Returns the cable names in a list, eg "SAT-3/WASC, WACS, and ACE".
+// Names start with a capital letter or a number, which drops words such as
+// "several".
+func cableNamesInList(s string) []string {
+	names := []string{}
+	s = strings.Replace(s, ", and ", ", ", -1)
+	for _, bit := range splitIgnoringParenthesis(s, ',') {
+		for _, name := range strings.Split(bit, " and ") {
+			name = strings.TrimSpace(name)
+			name = strings.TrimPrefix(name, "the ")
+			name = strings.TrimSpace(strings.TrimSuffix(name, "."))
+			if len(name) == 0 {
+				continue
+			}
+			if !startsWithCapitalLetter(name) && !startsWithNumber(name) {
+				continue
+			}
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Returns the names of the submarine cables in s
+func submarineCables(s string) []string {
+	cables := []string{}
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		m := submarineCableListRe.FindStringSubmatch(part)
+		if m != nil {
+			cables = append(cables, cableNamesInList(m[1])...)
+			continue
+		}
+		m = submarineCableNamesRe.FindStringSubmatch(part)
+		if m == nil {
+			continue
+		}
+		names := cableNameStartRe.ReplaceAllString(m[1], "")
+		cables = append(cables, cableNamesInList(names)...)
+	}
+	return cables
+}
+
+func satelliteOperatorName(s string) string {
+	for _, name := range satelliteOperators {
+		if strings.EqualFold(name, s) {
+			return name
+		}
+	}
+	return s
+}
+
+// Returns the total number of satellite earth stations and the operators
+// with their counts, eg "satellite earth stations - 61 Intelsat (45 Atlantic
+// Ocean and 16 Pacific Ocean), 5 Intersputnik" is 66 stations.
+func satelliteEarthStations(s string) (*orderedmap.OrderedMap, bool) {
+	o := orderedmap.New()
+	operators := []*orderedmap.OrderedMap{}
+	operatorTotal := 0
+	total := 0
+	hasTotal := false
+	for _, part := range strings.Split(s, ";") {
+		if !satelliteEarthStationRe.MatchString(part) {
+			continue
+		}
+		for _, m := range satelliteOperatorRe.FindAllStringSubmatch(part, -1) {
+			count, _ := strconv.Atoi(m[1])
+			operator := orderedmap.New()
+			operator.Set("operator", satelliteOperatorName(m[2]))
+			operator.Set("count", count)
+			note := trimSpaceAndParenthesis(m[3])
+			if len(note) > 0 {
+				operator.Set("note", note)
+			}
+			operators = append(operators, operator)
+			operatorTotal = operatorTotal + count
+		}
+		m := earthStationTotalRe.FindStringSubmatch(part)
+		if m == nil {
+			m = earthStationCountRe.FindStringSubmatch(part)
+		}
+		if m != nil && !hasTotal {
+			total, _ = strconv.Atoi(m[1])
+			hasTotal = true
+		}
+	}
+	// the total is the sum of the operators when the first number is for
+	// an operator, eg "satellite earth stations - 3 Intelsat"
+	if len(operators) > 0 && (!hasTotal || total < operatorTotal) {
+		total = operatorTotal
+		hasTotal = true
+	}
+	if !hasTotal {
+		return o, false
+	}
+	o.Set("total", total)
+	if len(operators) > 0 {
+		o.Set("operators", operators)
+	}
+	return o, true
+}
+
+// Returns the teledensity values in s, each with the type of line, which is
+// fixed_line, mobile_cellular, combined or total when the type is not given
+func teledensities(s string) []*orderedmap.OrderedMap {
+	list := []*orderedmap.OrderedMap{}
+	for _, m := range teledensityRe.FindAllStringSubmatchIndex(s, -1) {
+		value, err := strconv.ParseFloat(s[m[2]:m[3]], 64)
+		if err != nil {
+			continue
+		}
+		before := strings.ToLower(s[0:m[0]])
+		if i := strings.LastIndexAny(before, ";.,"); i > -1 {
+			before = before[i+1 : len(before)]
+		}
+		hasFixed := strings.Index(before, "fixed") > -1
+		hasMobile := strings.Index(before, "mobile") > -1
+		lineType := "total"
+		if strings.Index(before, "combined") > -1 || (hasFixed && hasMobile) {
+			lineType = "combined"
+		} else if hasMobile {
+			lineType = "mobile_cellular"
+		} else if hasFixed {
+			lineType = "fixed_line"
+		}
+		o := orderedmap.New()
+		o.Set("type", lineType)
+		o.Set("per_100_persons", value)
+		list = append(list, o)
+	}
+	return list
+}
+
+func telephoneSystemDetails(s string) *orderedmap.OrderedMap {
+	o := orderedmap.New()
+	o.Set("text", s)
+	cables := submarineCables(s)
+	if len(cables) > 0 {
+		o.Set("submarine_cables", cables)
+	}
+	stations, hasStations := satelliteEarthStations(s)
+	if hasStations {
+		o.Set("satellite_earth_stations", stations)
+	}
+	t := teledensities(s)
+	if len(t) > 0 {
+		o.Set("teledensity", t)
+	}
+	return o
+}
+
+func stationCount(s string) (int, bool) {
+	count, isWord := stationNumberWords[strings.ToLower(s)]
+	if isWord {
+		return count, true
+	}
+	count, err := strconv.Atoi(strings.Replace(s, ",", "", -1))
+	return count, err == nil
+}
+
+func broadcastMedium(s string) string {
+	s = strings.ToLower(s)
+	if s == "television" {
+		return "tv"
+	}
+	return s
+}
+
+// Returns state, private or unspecified from the words describing the
+// stations, or from the nearest owner named before them in the same part
+func broadcastOwnership(words, before string) string {
+	if privateOwnershipRe.MatchString(words) {
+		return "private"
+	}
+	if stateOwnershipRe.MatchString(words) {
+		return "state"
+	}
+	statePositions := stateOwnershipRe.FindAllStringIndex(before, -1)
+	privatePositions := privateOwnershipRe.FindAllStringIndex(before, -1)
+	stateIndex, privateIndex := -1, -1
+	if len(statePositions) > 0 {
+		stateIndex = statePositions[len(statePositions)-1][0]
+	}
+	if len(privatePositions) > 0 {
+		privateIndex = privatePositions[len(privatePositions)-1][0]
+	}
+	if privateIndex > stateIndex {
+		return "private"
+	}
+	if stateIndex > -1 {
+		return "state"
+	}
+	return "unspecified"
+}
+
+// Returns the stations counted in broadcast media, eg "state-owned TV and
+// radio broadcaster operates 2 TV stations and 3 radio stations; 5
+// privately owned TV stations" is 2 state tv, 3 state radio and 5 private
+// tv stations. Counts of channels and networks are kept separately from
+// stations using the unit.
+func broadcastStations(s string) []*orderedmap.OrderedMap {
+	stations := []*orderedmap.OrderedMap{}
+	for _, part := range strings.Split(s, ";") {
+		for _, m := range broadcastStationRe.FindAllStringSubmatchIndex(part, -1) {
+			count, isCount := stationCount(part[m[4]:m[5]])
+			if !isCount {
+				continue
+			}
+			medium := broadcastMedium(part[m[8]:m[9]])
+			if m[10] > -1 {
+				other := broadcastMedium(part[m[10]:m[11]])
+				if other != medium {
+					medium = "tv_and_radio"
+				}
+			}
+			unit := strings.TrimSuffix(strings.ToLower(part[m[12]:m[13]]), "s")
+			o := orderedmap.New()
+			o.Set("medium", medium)
+			o.Set("unit", unit)
+			o.Set("ownership", broadcastOwnership(part[m[6]:m[7]], part[0:m[0]]))
+			o.Set("count", count)
+			o.Set("approximate", m[2] > -1 || strings.Index(strings.ToLower(part[m[4]:m[5]]), "dozen") > -1)
+			stations = append(stations, o)
+		}
+	}
+	return stations
+}
+
+// Sums the stations by medium and ownership, eg tv: {state: 2, private: 5}
+func broadcastStationTotals(stations []*orderedmap.OrderedMap) *orderedmap.OrderedMap {
+	totals := orderedmap.New()
+	for _, station := range stations {
+		unit, _ := station.Get("unit")
+		if unit != "station" {
+			continue
+		}
+		mediumValue, _ := station.Get("medium")
+		ownershipValue, _ := station.Get("ownership")
+		countValue, _ := station.Get("count")
+		medium := mediumValue.(string)
+		ownership := ownershipValue.(string)
+		mediumTotals, exists := totals.Get(medium)
+		if !exists {
+			mediumTotals = orderedmap.New()
+			totals.Set(medium, mediumTotals)
+		}
+		t := mediumTotals.(*orderedmap.OrderedMap)
+		existing, _ := t.Get(ownership)
+		existingCount, _ := existing.(int)
+		t.Set(ownership, existingCount+countValue.(int))
+	}
+	return totals
+}
@@ -0,0 +1,110 @@
+package country
+
+import (
+	"orderedmap"
+	"strings"
+	"testing"
+)
+
+type SubmarineCableCase struct {
+	s        string
+	expected string
+}
+
+var submarineCableCases = []SubmarineCableCase{
+	// see Angola
+	SubmarineCableCase{
+		s:        "country code - 244; landing point for the SAT-3/WASC and the West Africa Cable System (WACS) fiber-optic submarine cables; satellite earth stations - 29 (2016)",
+		expected: "SAT-3/WASC|West Africa Cable System (WACS)",
+	},
+	SubmarineCableCase{
+		s:        "country code - 61; landing points for the SEA-ME-WE-3, Telstra Endeavour, and Australia-Japan Cable submarine cables",
+		expected: "SEA-ME-WE-3|Telstra Endeavour|Australia-Japan Cable",
+	},
+	SubmarineCableCase{
+		s:        "submarine cables: SAFE, SAT-3/WASC",
+		expected: "SAFE|SAT-3/WASC",
+	},
+	// no names
+	SubmarineCableCase{
+		s:        "country code - 1; multiple ocean cable systems provide international connectivity; several international submarine cables",
+		expected: "",
+	},
+}
+
+func TestSubmarineCables(t *testing.T) {
+	for testIndex, c := range submarineCableCases {
+		cables := strings.Join(submarineCables(c.s), "|")
+		if cables != c.expected {
+			t.Error("submarineCables, testIndex: ", testIndex, cables)
+		}
+	}
+}
+
+func TestSatelliteEarthStations(t *testing.T) {
+	// see United States
+	s := "country code - 1; satellite earth stations - 61 Intelsat (45 Atlantic Ocean and 16 Pacific Ocean), 5 Intersputnik (Atlantic Ocean region), and 4 Inmarsat (Pacific and Atlantic Ocean regions) (2007)"
+	o, hasStations := satelliteEarthStations(s)
+	if !hasStations {
+		t.Error("satelliteEarthStations no stations")
+		return
+	}
+	total, _ := o.Get("total")
+	if total != 70 {
+		t.Error("satelliteEarthStations total", total)
+	}
+	operators, _ := o.Get("operators")
+	if len(operators.([]*orderedmap.OrderedMap)) != 3 {
+		t.Error("satelliteEarthStations operators", operators)
+	}
+	note, _ := operators.([]*orderedmap.OrderedMap)[0].Get("note")
+	if note != "45 Atlantic Ocean and 16 Pacific Ocean" {
+		t.Error("satelliteEarthStations note", note)
+	}
+	// total without operators
+	o, _ = satelliteEarthStations("satellite earth stations - 29 (2016)")
+	total, _ = o.Get("total")
+	if total != 29 {
+		t.Error("satelliteEarthStations total without operators", total)
+	}
+}
+
+func TestTeledensities(t *testing.T) {
+	s := "fixed-line teledensity about 1 per 100 persons; combined fixed-line and mobile-cellular teledensity about 45 telephones per 100 persons"
+	list := teledensities(s)
+	if len(list) != 2 {
+		t.Error("teledensities count", len(list))
+		return
+	}
+	expected := []string{"fixed_line", "combined"}
+	expectedValues := []float64{1, 45}
+	for i, o := range list {
+		lineType, _ := o.Get("type")
+		value, _ := o.Get("per_100_persons")
+		if lineType != expected[i] || value != expectedValues[i] {
+			t.Error("teledensities", i, lineType, value)
+		}
+	}
+}
+
+func TestBroadcastStations(t *testing.T) {
+	s := "state-owned TV and radio broadcaster operates 2 TV stations and 3 radio stations; 5 privately owned TV stations and about 20 private radio stations; a half-dozen independent radio stations"
+	totals := broadcastStationTotals(broadcastStations(s))
+	expected := map[string]map[string]int{
+		"tv":    map[string]int{"state": 2, "private": 5},
+		"radio": map[string]int{"state": 3, "private": 26},
+	}
+	for medium, ownerships := range expected {
+		mediumTotals, exists := totals.Get(medium)
+		if !exists {
+			t.Error("broadcastStations missing", medium)
+			continue
+		}
+		for ownership, count := range ownerships {
+			v, _ := mediumTotals.(*orderedmap.OrderedMap).Get(ownership)
+			if v != count {
+				t.Error("broadcastStations", medium, ownership, v)
+			}
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package country
+
+import (
+	"orderedmap"
+	"sort"
+	"strings"
+)
+
+// Territories which are part of another page and do not have their own
+// name in the codes list, eg metropolitan France on the France page.
+var territoryNameExtras = []string{
+	"metropolitan France",
+	"metropolitan Netherlands",
+	"European Netherlands",
+	"Caribbean Netherlands",
+	"Bonaire",
+	"Saba",
+	"Sint Eustatius",
+	"Saint Helena",
+	"Ascension Island",
+	"Tristan da Cunha",
+	"Tristan da Cunha island group",
+	"overseas departments",
+}
+
+// The name used for the values which cover every territory on the page,
+// eg the first area on the France page includes the overseas regions.
+const allTerritoriesName = "all territories"
+
+var territoryNamesLongestFirst = []string{}
+
+func init() {
+	seen := map[string]bool{}
+	for _, c := range codesList {
+		if !seen[c.Name] {
+			territoryNamesLongestFirst = append(territoryNamesLongestFirst, c.Name)
+			seen[c.Name] = true
+		}
+	}
+	for _, name := range territoryNameExtras {
+		if !seen[name] {
+			territoryNamesLongestFirst = append(territoryNamesLongestFirst, name)
+			seen[name] = true
+		}
+	}
+	sort.Slice(territoryNamesLongestFirst, func(i, j int) bool {
+		return len(territoryNamesLongestFirst[i]) > len(territoryNamesLongestFirst[j])
+	})
+}
+
+type territoryValue struct {
+	name  string
+	value string
+}
+
+// Returns the territory name at the start of a line and the rest of the line,
+// eg "French Guiana: 4 00 N, 53 00 W" or "French Guiana - total: 1,205 km"
+func territoryAtStartOfLine(line string) (string, string, bool) {
+	line = strings.TrimSpace(line)
+	for _, name := range territoryNamesLongestFirst {
+		for _, sep := range []string{":", " - "} {
+			if startsWith(line, name+sep) {
+				rest := strings.TrimSpace(line[len(name+sep):len(line)])
+				return name, rest, true
+			}
+		}
+	}
+	return "", line, false
+}
+
+// Splits a field into the value for each territory where each territory
+// starts a new line. Lines without a territory belong to the territory
+// before them. Fields with less than two territories are not split.
+// see France and Saint Helena, Ascension, and Tristan da Cunha
+func splitByTerritory(s string) []territoryValue {
+	values := []territoryValue{}
+	for _, line := range strings.Split(s, "\n") {
+		name, rest, isTerritory := territoryAtStartOfLine(line)
+		if isTerritory {
+			values = append(values, territoryValue{name, rest})
+			continue
+		}
+		if len(values) == 0 {
+			// text before the first territory is not split
+			return []territoryValue{}
+		}
+		last := len(values) - 1
+		values[last].value = strings.TrimSpace(values[last].value + "\n" + line)
+	}
+	if len(values) < 2 {
+		return []territoryValue{}
+	}
+	return values
+}
+
+// Returns the value for the page in fields which list several territories,
+// which is the value of the first territory, eg metropolitan France. The value
+// for each territory is under territories.
+func pageTerritoryValue(s string) string {
+	values := splitByTerritory(s)
+	if len(values) == 0 {
+		return s
+	}
+	return values[0].value
+}
+
+// Splits a field where the value for the page comes first and the values for
+// other territories follow on their own lines, eg the ethnic groups of France
+// followed by "overseas departments: black, white, mulatto, ..."
+// Returns the value for the page and the value for each other territory.
+func splitPageAndTerritories(s string) (string, []territoryValue) {
+	pageLines := []string{}
+	values := []territoryValue{}
+	for _, line := range strings.Split(s, "\n") {
+		name, rest, isTerritory := territoryAtStartOfLine(line)
+		if isTerritory {
+			values = append(values, territoryValue{name, rest})
+			continue
+		}
+		if len(values) == 0 {
+			pageLines = append(pageLines, line)
+			continue
+		}
+		last := len(values) - 1
+		values[last].value = strings.TrimSpace(values[last].value + "\n" + line)
+	}
+	// every line is a territory, the first is the value for the page
+	if len(pageLines) == 0 {
+		if len(values) < 2 {
+			return s, []territoryValue{}
+		}
+		return values[0].value, values
+	}
+	return strings.Join(pageLines, "\n"), values
+}
+
+// Splits a map of values where each value lists the territories in
+// parenthesis, eg
+// total: 643,801 sq km; 551,500 sq km (metropolitan France)
+// The value without a territory covers all territories.
+// Notes are not split.
+func splitMapByTerritory(s string) []territoryValue {
+	values := []territoryValue{}
+	indexForName := map[string]int{}
+	m, err := stringToMap(s)
+	if err != nil {
+		return values
+	}
+	for _, key := range m.Keys() {
+		if key == "note" {
+			continue
+		}
+		v, _ := m.Get(key)
+		for _, part := range strings.Split(v.(string), "; ") {
+			value, ps := removeParenthesis(part)
+			name := allTerritoriesName
+			for _, p := range ps {
+				pName, _, isTerritory := territoryAtStartOfLine(p + ":")
+				if isTerritory {
+					name = pName
+				}
+			}
+			i, exists := indexForName[name]
+			if !exists {
+				i = len(values)
+				indexForName[name] = i
+				values = append(values, territoryValue{name, ""})
+			}
+			line := strings.Replace(key, "_", " ", -1) + ": " + strings.TrimSpace(value)
+			values[i].value = strings.TrimSpace(values[i].value + "\n" + line)
+		}
+	}
+	if len(values) < 2 {
+		return []territoryValue{}
+	}
+	return values
+}
+
+// Territories are listed in the order they first appear in the fields,
+// each with the fields which have a value for that territory.
+func (p *Page) territories() (interface{}, error) {
+	territories := []*orderedmap.OrderedMap{}
+	indexForName := map[string]int{}
+	addValues := func(key string, values []territoryValue, valueFn func(string) (interface{}, error)) {
+		for _, tv := range values {
+			v, err := valueFn(tv.value)
+			if err != nil {
+				continue
+			}
+			i, exists := indexForName[tv.name]
+			if !exists {
+				i = len(territories)
+				indexForName[tv.name] = i
+				t := orderedmap.New()
+				t.Set("name", tv.name)
+				territories = append(territories, t)
+			}
+			territories[i].Set(key, v)
+		}
+	}
+	textFor := func(selector Selector) string {
+		s, err := textForSelector(p.dom, selector)
+		if err != nil {
+			return ""
+		}
+		return strings.Replace(s, "\t", " ", -1)
+	}
+	// area
+	areas := textFor(Selector{"2147", "geography-area"})
+	areas = strings.Replace(areas, "NEGL", "0 sq km", -1)
+	addValues("area", splitMapByTerritory(areas), territoryArea)
+	// boundaries
+	boundaries := textFor(Selector{"2096", "geography-land-boundaries"})
+	addValues("land_boundaries", splitByTerritory(boundaries), landBoundaries)
+	// coordinates
+	coordinates := textFor(Selector{"2011", "geography-geographic-coordinates"})
+	addValues("geographic_coordinates", splitByTerritory(coordinates), geographicCoordinates)
+	// fields with one line per territory
+	lineFields := []struct {
+		key      string
+		selector Selector
+		valueFn  func(string) (interface{}, error)
+	}{
+		{"location", Selector{"2144", "geography-location"}, geographyLocation},
+		{"map_references", Selector{"2145", "geography-map-references"}, mapReferences},
+		{"coastline", Selector{"2060", "geography-coastline"}, coastline},
+		{"climate", Selector{"2059", "geography-climate"}, climate},
+		{"terrain", Selector{"2125", "geography-terrain"}, terrain},
+		{"natural_resources", Selector{"2111", "geography-natural-resources"}, naturalResources},
+		{"irrigated_land", Selector{"2146", "geography-irrigated-land"}, irrigatedLand},
+		{"natural_hazards", Selector{"2021", "geography-natural-hazards"}, naturalHazards},
+		{"illicit_drugs", Selector{"2086", "transnational-issues-illicit-drugs"}, illicitDrugs},
+	}
+	for _, f := range lineFields {
+		addValues(f.key, splitByTerritory(textFor(f.selector)), f.valueFn)
+	}
+	// fields where the value for the page comes first
+	_, ethnicities := splitPageAndTerritories(textFor(Selector{"2075", "people-and-society-ethnic-groups"}))
+	addValues("ethnic_groups", ethnicities, ethnicGroups)
+	_, languageValues := splitPageAndTerritories(textFor(Selector{"2098", "people-and-society-languages"}))
+	addValues("languages", languageValues, languages)
+	if len(territories) == 0 {
+		return territories, NoValueErr
+	}
+	return territories, nil
+}
+
+func territoryArea(value string) (interface{}, error) {
+	return stringToMapOfNumbersWithUnits(value)
+}
@@ -0,0 +1,121 @@
+package country
+
+import (
+	"orderedmap"
+	"testing"
+)
+
+func TestSplitByTerritory(t *testing.T) {
+	// see France
+	s := "metropolitan France: 46 00 N, 2 00 E\nFrench Guiana: 4 00 N, 53 00 W\nMartinique: 14 40 N, 61 00 W"
+	values := splitByTerritory(s)
+	if len(values) != 3 {
+		t.Error("splitByTerritory length", len(values))
+		return
+	}
+	if values[1].name != "French Guiana" || values[1].value != "4 00 N, 53 00 W" {
+		t.Error("splitByTerritory value", values[1])
+	}
+	// see France land boundaries, values continue over several lines
+	s = "metropolitan France - total: 2,751 km\nborder countries (8): Andorra 55 km, Belgium 556 km\nFrench Guiana - total: 1,205 km\nborder countries (2): Brazil 649 km, Suriname 556 km"
+	values = splitByTerritory(s)
+	if len(values) != 2 {
+		t.Error("splitByTerritory multiline length", len(values))
+		return
+	}
+	b, err := landBoundaries(values[1].value)
+	if err != nil {
+		t.Error("splitByTerritory multiline land boundaries", err)
+		return
+	}
+	countries, _ := b.(*orderedmap.OrderedMap).Get("border_countries")
+	if len(countries.([]*orderedmap.OrderedMap)) != 2 {
+		t.Error("splitByTerritory multiline border countries", countries)
+	}
+	// single territory pages are not split
+	values = splitByTerritory("46 00 N, 2 00 E")
+	if len(values) != 0 {
+		t.Error("splitByTerritory single territory", values)
+	}
+}
+
+func TestSplitMapByTerritory(t *testing.T) {
+	// see France
+	s := "total: 643,801 sq km; 551,500 sq km (metropolitan France)\nland: 640,427 sq km; 549,970 sq km (metropolitan France)\nnote: the first numbers include the overseas regions"
+	values := splitMapByTerritory(s)
+	if len(values) != 2 {
+		t.Error("splitMapByTerritory length", len(values))
+		return
+	}
+	if values[0].name != allTerritoriesName || values[0].value != "total: 643,801 sq km\nland: 640,427 sq km" {
+		t.Error("splitMapByTerritory all territories", values[0])
+	}
+	if values[1].name != "metropolitan France" {
+		t.Error("splitMapByTerritory name", values[1].name)
+	}
+	area, err := territoryArea(values[1].value)
+	if err != nil {
+		t.Error("splitMapByTerritory area", err)
+		return
+	}
+	total, _ := area.(*orderedmap.OrderedMap).Get("total")
+	v, _ := total.(*orderedmap.OrderedMap).Get("value")
+	if v.(float64) != 551500 {
+		t.Error("splitMapByTerritory area total", v)
+	}
+}
+
+func TestPageTerritoryValue(t *testing.T) {
+	// see France, the page value is the first territory
+	s := "metropolitan France: 46 00 N, 2 00 E\nFrench Guiana: 4 00 N, 53 00 W"
+	if v := pageTerritoryValue(s); v != "46 00 N, 2 00 E" {
+		t.Error("pageTerritoryValue first territory", v)
+	}
+	if v := pageTerritoryValue("46 00 N, 2 00 E"); v != "46 00 N, 2 00 E" {
+		t.Error("pageTerritoryValue single territory", v)
+	}
+	g, err := geographicCoordinates(s)
+	if err != nil {
+		t.Error("geographicCoordinates for several territories", err)
+		return
+	}
+	lat, _ := g.(*orderedmap.OrderedMap).Get("latitude")
+	if lat == nil {
+		t.Error("geographicCoordinates for several territories latitude", g)
+	}
+	b, err := landBoundaries("metropolitan France - total: 2,751 km\nborder countries (8): Andorra 55 km, Belgium 556 km\nFrench Guiana - total: 1,205 km\nborder countries (2): Brazil 649 km, Suriname 556 km")
+	if err != nil {
+		t.Error("landBoundaries for several territories", err)
+		return
+	}
+	countries, _ := b.(*orderedmap.OrderedMap).Get("border_countries")
+	if len(countries.([]*orderedmap.OrderedMap)) != 2 {
+		t.Error("landBoundaries for several territories border countries", countries)
+	}
+}
+
+func TestSplitPageAndTerritories(t *testing.T) {
+	// see France ethnic groups
+	s := "Celtic and Latin with Teutonic, Slavic, North African, Indochinese, Basque minorities\noverseas departments: black, white, mulatto, East Indian, Chinese, Amerindian"
+	page, values := splitPageAndTerritories(s)
+	if page != "Celtic and Latin with Teutonic, Slavic, North African, Indochinese, Basque minorities" {
+		t.Error("splitPageAndTerritories page", page)
+	}
+	if len(values) != 1 || values[0].name != "overseas departments" {
+		t.Error("splitPageAndTerritories territories", values)
+		return
+	}
+	o, err := ethnicGroups(s)
+	if err != nil {
+		t.Error("ethnicGroups for several territories", err)
+		return
+	}
+	if _, exists := o.(*orderedmap.OrderedMap).Get("overseas_departments"); exists {
+		t.Error("ethnicGroups should not include overseas departments")
+	}
+	// pages without other territories are unchanged
+	page, values = splitPageAndTerritories("French 100%")
+	if page != "French 100%" || len(values) != 0 {
+		t.Error("splitPageAndTerritories single territory", page, values)
+	}
+}
@@ -0,0 +1,141 @@
+package country
+
+import (
+	"orderedmap"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const timelineYearPattern = `(?:1[0-9]{3}|20[0-9]{2})`
+
+// eg 25 March 2015, July 4, 1776, March 2015, 1992
+var dateMentionRe = regexp.MustCompile(`\b(?:` +
+	`[0-9]{1,2} ` + monthsPattern + ` ` + timelineYearPattern + `|` +
+	monthsPattern + ` [0-9]{1,2}, ` + timelineYearPattern + `|` +
+	monthsPattern + ` ` + timelineYearPattern + `|` +
+	timelineYearPattern + `)\b`)
+
+// Words which identify the event for a date, checked in order.
+type timelineKeyword struct {
+	event string
+	re    *regexp.Regexp
+}
+
+var timelineKeywords = []timelineKeyword{
+	timelineKeyword{"unification", regexp.MustCompile(`(?i)\bunif`)},
+	timelineKeyword{"previous_constitution", regexp.MustCompile(`(?i)\bprevious`)},
+	timelineKeyword{"amendment", regexp.MustCompile(`(?i)\bamend`)},
+	timelineKeyword{"suspension", regexp.MustCompile(`(?i)\bsuspend`)},
+	timelineKeyword{"effective", regexp.MustCompile(`(?i)\beffective`)},
+	timelineKeyword{"adoption", regexp.MustCompile(`(?i)\b(latest|adopt(ed|ion|s)?|approv(al|e|ed|es)|ratif(y|ied|ication)|promulgat(e|ed|ion)|enact(ed|ment|s)?|sign(ed|ing|s)?)\b`)},
+	timelineKeyword{"independence", regexp.MustCompile(`(?i)\b(independen|declared|recogni|proclaim)`)},
+}
+
+// The event for dates without any keywords, by source field
+var timelineDefaultEvents = map[string]string{
+	"independence":            "independence",
+	"constitution.history":    "adoption",
+	"constitution.amendments": "amendment",
+}
+
+type timelineEvent struct {
+	date        factbookDate
+	event       string
+	description string
+	source      string
+}
+
+func timelineEventForText(s string) (string, bool) {
+	for _, k := range timelineKeywords {
+		if k.re.MatchString(s) {
+			return k.event, true
+		}
+	}
+	return "", false
+}
+
+// Returns every dated event in a field.
+// The event for a date comes from the words before it, then the words after
+// it, then the event for the previous date in the same part of the field,
+// eg "amended 1999, 2004, 2015" is three amendments.
+func timelineEventsForField(value, source string) []timelineEvent {
+	events := []timelineEvent{}
+	for _, part := range strings.Split(value, "; ") {
+		part = strings.TrimSpace(part)
+		matches := dateMentionRe.FindAllStringIndex(part, -1)
+		previousEvent := ""
+		for i, m := range matches {
+			d, err := stringToDate(part[m[0]:m[1]])
+			if err != nil {
+				continue
+			}
+			beforeStart := 0
+			if i > 0 {
+				beforeStart = matches[i-1][1]
+			}
+			afterEnd := len(part)
+			if i < len(matches)-1 {
+				afterEnd = matches[i+1][0]
+			}
+			event, found := timelineEventForText(part[beforeStart:m[0]])
+			if !found {
+				event, found = timelineEventForText(part[m[1]:afterEnd])
+			}
+			if !found && previousEvent != "" {
+				event, found = previousEvent, true
+			}
+			if !found {
+				event, found = timelineDefaultEvents[source]
+			}
+			if !found {
+				continue
+			}
+			previousEvent = event
+			events = append(events, timelineEvent{d, event, part, source})
+		}
+	}
+	return events
+}
+
+// The timeline lists the dated events from the independence and constitution
+// fields with the earliest first. Events with the same date keep the order
+// they appear on the page.
+func (p *Page) constitutionalTimeline() (interface{}, error) {
+	timeline := []*orderedmap.OrderedMap{}
+	events := []timelineEvent{}
+	value, err := textForSelector(p.dom, Selector{"2088", "government-independence"})
+	if err == nil {
+		value = strings.Replace(value, "\t", " ", -1)
+		events = append(events, timelineEventsForField(value, "independence")...)
+	}
+	value, err = textForSelector(p.dom, Selector{"2063", "government-constitution"})
+	if err == nil {
+		value = strings.Replace(value, "\t", " ", -1)
+		c, _ := constitution(value)
+		o := c.(*orderedmap.OrderedMap)
+		for _, key := range o.Keys() {
+			v, _ := o.Get(key)
+			vStr, isString := v.(string)
+			if !isString || key == "note" {
+				continue
+			}
+			events = append(events, timelineEventsForField(vStr, "constitution."+key)...)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].date.start.Before(events[j].date.start)
+	})
+	for _, e := range events {
+		o := orderedmap.New()
+		setDate(o, e.date)
+		o.Set("event", e.event)
+		o.Set("description", e.description)
+		o.Set("source", e.source)
+		timeline = append(timeline, o)
+	}
+	if len(timeline) == 0 {
+		return timeline, NoValueErr
+	}
+	return timeline, nil
+}
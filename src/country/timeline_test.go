@@ -0,0 +1,88 @@
+package country
+
+import (
+	"testing"
+)
+
+type TimelineEventsCase struct {
+	value          string
+	source         string
+	expectedDates  []string
+	expectedEvents []string
+}
+
+var timelineEventsCases = []TimelineEventsCase{
+	// see United States
+	TimelineEventsCase{
+		value:          "4 July 1776 (declared); 3 September 1783 (recognized by Great Britain)",
+		source:         "independence",
+		expectedDates:  []string{"1776-07-04", "1783-09-03"},
+		expectedEvents: []string{"independence", "independence"},
+	},
+	// see Germany
+	TimelineEventsCase{
+		value:          "3 October 1990 (West Germany and East Germany unify)",
+		source:         "independence",
+		expectedDates:  []string{"1990-10-03"},
+		expectedEvents: []string{"unification"},
+	},
+	TimelineEventsCase{
+		value:          "several previous; latest adopted 25 March 2015, effective 20 April 2015",
+		source:         "constitution.history",
+		expectedDates:  []string{"2015-03-25", "2015-04-20"},
+		expectedEvents: []string{"adoption", "effective"},
+	},
+	TimelineEventsCase{
+		value:          "previous 1921, 1936; latest adopted 1998",
+		source:         "constitution.history",
+		expectedDates:  []string{"1921-01-01", "1936-01-01", "1998-01-01"},
+		expectedEvents: []string{"previous_constitution", "previous_constitution", "adoption"},
+	},
+	TimelineEventsCase{
+		value:          "proposed by the Parliament; passage requires two-thirds majority vote; amended 1999, 2004, 2015",
+		source:         "constitution.amendments",
+		expectedDates:  []string{"1999-01-01", "2004-01-01", "2015-01-01"},
+		expectedEvents: []string{"amendment", "amendment", "amendment"},
+	},
+	TimelineEventsCase{
+		value:          "latest ratified 1992",
+		source:         "constitution.history",
+		expectedDates:  []string{"1992-01-01"},
+		expectedEvents: []string{"adoption"},
+	},
+	// words starting with an adoption keyword are not adoptions
+	TimelineEventsCase{
+		value:          "significant reforms 1991",
+		source:         "independence",
+		expectedDates:  []string{"1991-01-01"},
+		expectedEvents: []string{"independence"},
+	},
+	// no dates
+	TimelineEventsCase{
+		value:          "proposed by the Parliament",
+		source:         "constitution.amendments",
+		expectedDates:  []string{},
+		expectedEvents: []string{},
+	},
+}
+
+func TestTimelineEventsForField(t *testing.T) {
+	for testIndex, c := range timelineEventsCases {
+		events := timelineEventsForField(c.value, c.source)
+		if len(events) != len(c.expectedDates) {
+			t.Error("timelineEventsForField length, testIndex: ", testIndex, len(events))
+			continue
+		}
+		for i, e := range events {
+			if e.date.start.Format("2006-01-02") != c.expectedDates[i] {
+				t.Error("timelineEventsForField date, testIndex: ", testIndex, i, e.date.start)
+			}
+			if e.event != c.expectedEvents[i] {
+				t.Error("timelineEventsForField event, testIndex: ", testIndex, i, e.event)
+			}
+			if e.source != c.source {
+				t.Error("timelineEventsForField source, testIndex: ", testIndex, i, e.source)
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package country
+
+import (
+	"errors"
+	"orderedmap"
+	"regexp"
+	"strings"
+)
+
+var StringToTraffickingTierErr = errors.New("String is not a trafficking tier")
+
+// eg Tier 2 Watch List, Tier 3, Special Case and, from older weekly files,
+// tier_2_watch_list
+var traffickingTierRe = regexp.MustCompile(`(?i)^(?:tier[\s_]*([123])(?:[\s_]*(?:[-–]\s*)?watch[\s_]*list|\s*WL\b)?|special[\s_]+case)`)
+
+// eg the dash in "Tier 3 – Algeria does not fully comply..."
+var tierNarrativeStartRe = regexp.MustCompile(`^[\s\-–—:;,.]+`)
+
+// TraffickingTier is the ranking given in the Trafficking in Persons report.
+// Tiers are ordered from best to worst, so a larger tier is a downgrade.
+// Special cases are not ranked.
+type TraffickingTier int
+
+const (
+	Tier1 TraffickingTier = iota + 1
+	Tier2
+	Tier2WatchList
+	Tier3
+	TierSpecialCase
+)
+
+var traffickingTierKeys = map[TraffickingTier]string{
+	Tier1:           "tier_1",
+	Tier2:           "tier_2",
+	Tier2WatchList:  "tier_2_watch_list",
+	Tier3:           "tier_3",
+	TierSpecialCase: "special_case",
+}
+
+// Key is the same for a tier in any layout, eg tier_2_watch_list
+func (t TraffickingTier) Key() string {
+	return traffickingTierKeys[t]
+}
+
+// IsRanked is false for special cases, which can't be compared to tiers
+func (t TraffickingTier) IsRanked() bool {
+	return t >= Tier1 && t <= Tier3
+}
+
+// Returns the tier at the start of s, eg "Tier 2 Watch List - Albania does
+// not fully comply..." is Tier2WatchList, and the rest of s
+func stringToTraffickingTierAndRest(s string) (TraffickingTier, string, error) {
+	s = strings.TrimSpace(s)
+	m := traffickingTierRe.FindStringSubmatchIndex(s)
+	if m == nil {
+		return 0, s, StringToTraffickingTierErr
+	}
+	matched := strings.ToLower(s[m[0]:m[1]])
+	rest := s[m[1]:len(s)]
+	var t TraffickingTier
+	switch {
+	case strings.HasPrefix(matched, "special"):
+		t = TierSpecialCase
+	case s[m[2]:m[3]] == "1":
+		t = Tier1
+	case s[m[2]:m[3]] == "3":
+		t = Tier3
+	case strings.Contains(matched, "watch") || strings.Contains(matched, "wl"):
+		t = Tier2WatchList
+	default:
+		t = Tier2
+	}
+	return t, rest, nil
+}
+
+// Converts a tier rating to a tier, eg "Tier 2 Watch List" or
+// "tier_2_watch_list"
+func StringToTraffickingTier(s string) (TraffickingTier, error) {
+	t, _, err := stringToTraffickingTierAndRest(s)
+	return t, err
+}
+
+// Returns the tier, its year and the rest of the text as the narrative, eg
+// "Tier 2 Watch List – Albania does not fully comply with the minimum
+// standards... (2015)"
+func stringToTierRating(value string) (*orderedmap.OrderedMap, error) {
+	o := orderedmap.New()
+	value, date, hasDate := stringWithoutDateDetail(value)
+	t, rest, err := stringToTraffickingTierAndRest(value)
+	if err != nil {
+		return o, err
+	}
+	o.Set("tier", t.Key())
+	if hasDate {
+		setDate(o, date)
+	}
+	narrative := strings.TrimSpace(tierNarrativeStartRe.ReplaceAllString(rest, ""))
+	if len(narrative) > 0 {
+		o.Set("narrative", narrative)
+	}
+	return o, nil
+}
@@ -0,0 +1,83 @@
+package country
+
+import (
+	"testing"
+)
+
+type TierRatingCase struct {
+	s                 string
+	expectedTier      string
+	expectedDate      string
+	expectedNarrative string
+}
+
+var tierRatingCases = []TierRatingCase{
+	TierRatingCase{
+		s:                 "Tier 2 Watch List – Albania does not fully comply with the minimum standards for the elimination of trafficking (2015)",
+		expectedTier:      "tier_2_watch_list",
+		expectedDate:      "2015",
+		expectedNarrative: "Albania does not fully comply with the minimum standards for the elimination of trafficking",
+	},
+	TierRatingCase{
+		s:                 "Tier 3 - Algeria does not fully comply with the minimum standards",
+		expectedTier:      "tier_3",
+		expectedNarrative: "Algeria does not fully comply with the minimum standards",
+	},
+	TierRatingCase{
+		s:            "Tier 1 (2017)",
+		expectedTier: "tier_1",
+		expectedDate: "2017",
+	},
+	TierRatingCase{
+		s:                 "Tier 2 — the government does not fully meet the minimum standards (2020)",
+		expectedTier:      "tier_2",
+		expectedDate:      "2020",
+		expectedNarrative: "the government does not fully meet the minimum standards",
+	},
+	TierRatingCase{
+		s:            "Special Case (2018)",
+		expectedTier: "special_case",
+		expectedDate: "2018",
+	},
+}
+
+func TestStringToTierRating(t *testing.T) {
+	for testIndex, c := range tierRatingCases {
+		o, err := stringToTierRating(c.s)
+		if err != nil {
+			t.Error("stringToTierRating error, testIndex: ", testIndex, err)
+			continue
+		}
+		tier, _ := o.Get("tier")
+		if tier != c.expectedTier {
+			t.Error("stringToTierRating tier, testIndex: ", testIndex, tier)
+		}
+		date, hasDate := o.Get("date")
+		if (c.expectedDate == "" && hasDate) || (c.expectedDate != "" && date != c.expectedDate) {
+			t.Error("stringToTierRating date, testIndex: ", testIndex, date)
+		}
+		narrative, _ := o.Get("narrative")
+		if c.expectedNarrative != "" && narrative != c.expectedNarrative {
+			t.Error("stringToTierRating narrative, testIndex: ", testIndex, narrative)
+		}
+	}
+}
+
+func TestStringToTraffickingTier(t *testing.T) {
+	cases := map[string]TraffickingTier{
+		"tier_2_watch_list": Tier2WatchList,
+		"Tier 2WL":          Tier2WatchList,
+		"tier_2":            Tier2,
+		"special_case":      TierSpecialCase,
+	}
+	for s, expected := range cases {
+		tier, err := StringToTraffickingTier(s)
+		if err != nil || tier != expected {
+			t.Error("StringToTraffickingTier", s, tier, err)
+		}
+	}
+	_, err := StringToTraffickingTier("not rated")
+	if err != StringToTraffickingTierErr {
+		t.Error("StringToTraffickingTier should fail for text without a tier")
+	}
+}
@@ -0,0 +1,269 @@
+package country
+
+import (
+	"orderedmap"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// eg 1,589 km, 6,000 mi, 1.2 million km
+var transportLengthRe = regexp.MustCompile(`([0-9][0-9,]*(?:\.[0-9]+)?)\s*(thousand|million)?\s*\b(km|mi|m|nm)\b`)
+
+// eg "refined products 1,200 km" or, in later layouts, "1,200 km refined
+// products"
+var typeThenLengthRe = regexp.MustCompile(`^(.*?)\s*,?\s*([0-9][0-9,]*(?:\.[0-9]+)?\s*(?:thousand|million)?\s*(?:km|mi|m|nm))$`)
+var lengthThenTypeRe = regexp.MustCompile(`^([0-9][0-9,]*(?:\.[0-9]+)?\s*(?:thousand|million)?\s*(?:km|mi|m|nm))\s+(?:of\s+)?(.+)$`)
+
+var transportNoteRe = regexp.MustCompile(`^notes?\s*(?:-|:)\s*`)
+
+// Roadway and waterway keys from the different layouts, eg non-urban
+// becomes non_urban
+var transportTypeNames = map[string]string{
+	"nonurban":           "non_urban",
+	"non_urban_roads":    "non_urban",
+	"urban_roads":        "urban",
+	"paved_roads":        "paved",
+	"unpaved_roads":      "unpaved",
+	"paved_highways":     "paved",
+	"unpaved_highways":   "unpaved",
+	"highways_total":     "total",
+	"highways_paved":     "paved",
+	"highways_unpaved":   "unpaved",
+	"total_roads":        "total",
+	"navigable":          "navigable",
+	"navigable_waterway": "navigable",
+}
+
+// transportLength is a length of one type of pipeline, roadway or waterway
+type transportLength struct {
+	lengthType string
+	km         float64
+	date       factbookDate
+	hasDate    bool
+	note       string
+}
+
+func (l transportLength) toMap() *orderedmap.OrderedMap {
+	o := orderedmap.New()
+	o.Set("type", l.lengthType)
+	o.Set("length_km", l.km)
+	if l.hasDate {
+		o.Set("date", l.date.String())
+	}
+	if len(l.note) > 0 {
+		o.Set("note", l.note)
+	}
+	return o
+}
+
+// Converts the first length in s to km, eg 6,000 mi is 9656.064 km
+func stringToKm(s string) (float64, error) {
+	m := transportLengthRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, StringToNumberWithUnitsErr
+	}
+	value, err := strconv.ParseFloat(strings.Replace(m[1], ",", "", -1), 64)
+	if err != nil {
+		return 0, err
+	}
+	if m[2] == "thousand" {
+		value = value * 1e3
+	} else if m[2] == "million" {
+		value = value * 1e6
+	}
+	u, err := unitsToCanonical(m[3])
+	if err != nil {
+		return 0, err
+	}
+	return value * u.scale / 1e3, nil
+}
+
+func normaliseTransportType(s string) string {
+	key := stringToJsonKey(strings.TrimSpace(s))
+	name, exists := transportTypeNames[key]
+	if exists {
+		return name
+	}
+	return key
+}
+
+// Returns the lengths in a field with one type per line, eg roadways
+// "total: 52,000 km\npaved: 12,000 km\nunpaved: 40,000 km (2012)". A first
+// line without a key is the total, eg waterways "41,009 km (2012)". The date
+// at the end of the field applies to every type without its own date.
+func stringToTransportLengths(value string) ([]transportLength, *orderedmap.OrderedMap) {
+	lengths := []transportLength{}
+	other := orderedmap.New()
+	notes := []string{}
+	var fieldDate factbookDate
+	hasFieldDate := false
+	for i, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		lengthType := "total"
+		bits := strings.SplitN(line, ":", 2)
+		if len(bits) == 2 && len(bits[0]) < 100 {
+			lengthType = normaliseTransportType(bits[0])
+			line = strings.TrimSpace(bits[1])
+		} else if i > 0 {
+			notes = append(notes, line)
+			continue
+		}
+		if lengthType == "country_comparison_to_the_world" {
+			rank, err := stringToNumericValue(line)
+			if err == nil {
+				other.Set("global_rank", rank.lowerBound)
+			}
+			continue
+		}
+		if lengthType == "note" {
+			notes = append(notes, line)
+			continue
+		}
+		line, d, hasDate := stringWithoutDateDetail(line)
+		if hasDate {
+			fieldDate = d
+			hasFieldDate = true
+		}
+		line, ps := removeParenthesis(line)
+		km, err := stringToKm(line)
+		if err != nil {
+			continue
+		}
+		// eg 3,631 km of navigable rivers
+		rest := strings.TrimSpace(transportLengthRe.ReplaceAllString(line, ""))
+		if len(rest) > 0 {
+			ps = append([]string{rest}, ps...)
+		}
+		l := transportLength{
+			lengthType: lengthType,
+			km:         km,
+			date:       d,
+			hasDate:    hasDate,
+			note:       strings.TrimSpace(strings.Join(ps, "; ")),
+		}
+		lengths = append(lengths, l)
+	}
+	if len(notes) > 0 {
+		other.Set("note", strings.Join(notes, "; "))
+	}
+	if hasFieldDate {
+		for i := range lengths {
+			if !lengths[i].hasDate {
+				lengths[i].date = fieldDate
+				lengths[i].hasDate = true
+			}
+		}
+		setDate(other, fieldDate)
+	}
+	return lengths, other
+}
+
+// Splits s on a comma followed by a space outside parenthesis, so numbers
+// such as 1,589 are not split
+func splitOnCommaSpace(s string) []string {
+	bits := []string{}
+	for _, bit := range splitIgnoringParenthesis(strings.Replace(s, ", ", "\x00", -1), '\x00') {
+		bits = append(bits, strings.Replace(bit, "\x00", ", ", -1))
+	}
+	return bits
+}
+
+// Returns the lengths of each type of pipeline, eg "gas 1,589 km; oil 2,600
+// km (2013)" or "1,589 km gas, 2,600 km oil (2013)". Commas in numbers are
+// kept since items are only split on a comma followed by a space.
+func stringToPipelineLengths(value string) ([]transportLength, *orderedmap.OrderedMap) {
+	lengths := []transportLength{}
+	other := orderedmap.New()
+	notes := []string{}
+	var fieldDate factbookDate
+	hasFieldDate := false
+	// older layouts put the type and length in separate items, eg
+	// "crude oil, 1,000 km"
+	pendingType := ""
+	for _, part := range splitIgnoringParenthesis(value, ';') {
+		part = strings.TrimSpace(part)
+		if transportNoteRe.MatchString(part) {
+			notes = append(notes, transportNoteRe.ReplaceAllString(part, ""))
+			continue
+		}
+		for _, item := range splitOnCommaSpace(part) {
+			item = strings.TrimSpace(item)
+			if len(item) == 0 {
+				continue
+			}
+			item, d, hasDate := stringWithoutDateDetail(item)
+			if hasDate {
+				fieldDate = d
+				hasFieldDate = true
+			}
+			item, ps := removeParenthesis(item)
+			item = strings.TrimSpace(item)
+			pipeType := ""
+			lengthStr := ""
+			m := lengthThenTypeRe.FindStringSubmatch(item)
+			if m != nil {
+				lengthStr = m[1]
+				pipeType = m[2]
+			} else {
+				m = typeThenLengthRe.FindStringSubmatch(item)
+				if m == nil {
+					pendingType = item
+					continue
+				}
+				pipeType = m[1]
+				lengthStr = m[2]
+			}
+			if len(pipeType) == 0 {
+				pipeType = pendingType
+			}
+			pendingType = ""
+			km, err := stringToKm(lengthStr)
+			if err != nil || len(pipeType) == 0 {
+				continue
+			}
+			l := transportLength{
+				lengthType: strings.ToLower(strings.Join(strings.Fields(pipeType), " ")),
+				km:         km,
+				date:       d,
+				hasDate:    hasDate,
+				note:       strings.TrimSpace(strings.Join(ps, "; ")),
+			}
+			lengths = append(lengths, l)
+		}
+	}
+	if len(notes) > 0 {
+		other.Set("note", strings.Join(notes, "; "))
+	}
+	if hasFieldDate {
+		for i := range lengths {
+			if !lengths[i].hasDate {
+				lengths[i].date = fieldDate
+				lengths[i].hasDate = true
+			}
+		}
+		setDate(other, fieldDate)
+	}
+	return lengths, other
+}
+
+// Combines the lengths with the other values for the field, eg global_rank
+func transportNetwork(lengths []transportLength, other *orderedmap.OrderedMap) (*orderedmap.OrderedMap, error) {
+	o := orderedmap.New()
+	if len(lengths) == 0 && len(other.Keys()) == 0 {
+		return o, NoValueErr
+	}
+	byType := []*orderedmap.OrderedMap{}
+	for _, l := range lengths {
+		byType = append(byType, l.toMap())
+	}
+	o.Set("by_type", byType)
+	for _, key := range other.Keys() {
+		v, _ := other.Get(key)
+		o.Set(key, v)
+	}
+	return o, nil
+}
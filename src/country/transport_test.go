@@ -0,0 +1,94 @@
+package country
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+type TransportLengthCase struct {
+	s             string
+	expectedTypes []string
+	expectedKm    []float64
+	expectedDate  string
+}
+
+var pipelineCases = []TransportLengthCase{
+	TransportLengthCase{
+		s:             "condensate 1,200 km; gas 21,000 km; liquid petroleum gas 300 km; oil/gas/water 12 km (2013)",
+		expectedTypes: []string{"condensate", "gas", "liquid petroleum gas", "oil/gas/water"},
+		expectedKm:    []float64{1200, 21000, 300, 12},
+		expectedDate:  "2013",
+	},
+	// later layout with the length first
+	TransportLengthCase{
+		s:             "12,446 km gas, 2,206 km oil, 1,563 km refined products (2020)",
+		expectedTypes: []string{"gas", "oil", "refined products"},
+		expectedKm:    []float64{12446, 2206, 1563},
+		expectedDate:  "2020",
+	},
+	// older layout with the type and length in separate items
+	TransportLengthCase{
+		s:             "crude oil, 1,000 mi; natural gas 850 km",
+		expectedTypes: []string{"crude oil", "natural gas"},
+		expectedKm:    []float64{1609.344, 850},
+	},
+}
+
+var roadwayCases = []TransportLengthCase{
+	TransportLengthCase{
+		s:             "total: 6,586,610 km\npaved: 4,304,715 km (includes 76,334 km of expressways)\nunpaved: 2,281,895 km (2012)\ncountry comparison to the world: 1",
+		expectedTypes: []string{"total", "paved", "unpaved"},
+		expectedKm:    []float64{6586610, 4304715, 2281895},
+		expectedDate:  "2012",
+	},
+	TransportLengthCase{
+		s:             "total: 52,000 km\nurban: 12,000 km\nnon-urban: 40,000 km (2011)",
+		expectedTypes: []string{"total", "urban", "non_urban"},
+		expectedKm:    []float64{52000, 12000, 40000},
+		expectedDate:  "2011",
+	},
+	// waterways
+	TransportLengthCase{
+		s:             "41,009 km (19,312 km used for commerce; Atlantic Intracoastal and Gulf Intracoastal Waterways not included) (2012)\ncountry comparison to the world: 5",
+		expectedTypes: []string{"total"},
+		expectedKm:    []float64{41009},
+		expectedDate:  "2012",
+	},
+}
+
+func checkTransportLengths(t *testing.T, name string, testIndex int, c TransportLengthCase, lengths []transportLength) {
+	if len(lengths) != len(c.expectedTypes) {
+		t.Error(name, "count, testIndex: ", testIndex, len(lengths))
+		return
+	}
+	for i, l := range lengths {
+		if l.lengthType != c.expectedTypes[i] {
+			t.Error(name, "type, testIndex: ", testIndex, l.lengthType)
+		}
+		if math.Abs(l.km-c.expectedKm[i]) > 1e-6 {
+			t.Error(name, "km, testIndex: ", testIndex, l.km)
+		}
+		if len(c.expectedDate) > 0 && l.date.String() != c.expectedDate {
+			t.Error(name, "date, testIndex: ", testIndex, l.date.String())
+		}
+	}
+}
+
+func TestPipelineLengths(t *testing.T) {
+	for testIndex, c := range pipelineCases {
+		lengths, _ := stringToPipelineLengths(c.s)
+		checkTransportLengths(t, "stringToPipelineLengths", testIndex, c, lengths)
+	}
+}
+
+func TestTransportLengths(t *testing.T) {
+	for testIndex, c := range roadwayCases {
+		lengths, other := stringToTransportLengths(c.s)
+		checkTransportLengths(t, "stringToTransportLengths", testIndex, c, lengths)
+		_, hasRank := other.Get("global_rank")
+		if strings.Contains(c.s, "country comparison") && !hasRank {
+			t.Error("stringToTransportLengths global_rank, testIndex: ", testIndex)
+		}
+	}
+}
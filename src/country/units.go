@@ -0,0 +1,157 @@
+package country
+
+import (
+	"errors"
+	"orderedmap"
+	"strings"
+)
+
+var UnknownUnitsErr = errors.New("Units are not in the units registry")
+
+// unitDefinition describes how a value in some units converts to the
+// canonical units for its dimension, eg 1 sq km is 1e6 sq m
+type unitDefinition struct {
+	dimension      string
+	canonicalUnits string
+	scale          float64
+}
+
+const secondsPerYear = 31557600             // julian year
+const cubicMetresPerBarrel = 0.158987294928 // US oil barrel
+const secondsPerDay = 86400
+
+// Canonical units are SI wherever the dimension has an SI unit.
+// Ratios are expressed as a plain fraction, so 5% has value_si 0.05.
+var unitRegistry = map[string]unitDefinition{
+	// length
+	"m":  unitDefinition{"length", "m", 1},
+	"km": unitDefinition{"length", "m", 1e3},
+	"nm": unitDefinition{"length", "m", 1852},
+	"mi": unitDefinition{"length", "m", 1609.344},
+	// area
+	"sq m":  unitDefinition{"area", "sq m", 1},
+	"sq km": unitDefinition{"area", "sq m", 1e6},
+	"sq mi": unitDefinition{"area", "sq m", 2589988.110336},
+	// volume
+	"cu m":         unitDefinition{"volume", "cu m", 1},
+	"cu km":        unitDefinition{"volume", "cu m", 1e9},
+	"cubic_metres": unitDefinition{"volume", "cu m", 1},
+	"bbl":          unitDefinition{"volume", "cu m", cubicMetresPerBarrel},
+	// volume per time
+	"bbl_per_day": unitDefinition{"volume_flow", "cu m/s", cubicMetresPerBarrel / secondsPerDay},
+	"bbl/day":     unitDefinition{"volume_flow", "cu m/s", cubicMetresPerBarrel / secondsPerDay},
+	// energy and power
+	"kWh": unitDefinition{"energy", "J", 3.6e6},
+	"kW":  unitDefinition{"power", "W", 1e3},
+	// mass
+	"megatonnes": unitDefinition{"mass", "kg", 1e9},
+	"Mt":         unitDefinition{"mass", "kg", 1e9},
+	// time
+	"year":  unitDefinition{"time", "s", secondsPerYear},
+	"years": unitDefinition{"time", "s", secondsPerYear},
+	// currency
+	"USD": unitDefinition{"currency", "USD", 1},
+	// ratios
+	"%":                           unitDefinition{"ratio", "fraction", 0.01},
+	"percent":                     unitDefinition{"ratio", "fraction", 0.01},
+	"percent_of_gdp":              unitDefinition{"ratio", "fraction", 0.01},
+	"percent of population":       unitDefinition{"ratio", "fraction", 0.01},
+	"deaths_per_1000_live_births": unitDefinition{"ratio", "fraction", 1e-3},
+	"males/female":                unitDefinition{"ratio", "fraction", 1},
+	// rates
+	"births/1,000 population":     unitDefinition{"ratio", "fraction", 1e-3},
+	"deaths/1,000 population":     unitDefinition{"ratio", "fraction", 1e-3},
+	"migrants/1,000 population":   unitDefinition{"ratio", "fraction", 1e-3},
+	"physicians/1,000 population": unitDefinition{"ratio", "fraction", 1e-3},
+	"beds/1,000 population":       unitDefinition{"ratio", "fraction", 1e-3},
+	"deaths/100,000 live births":  unitDefinition{"ratio", "fraction", 1e-5},
+	"children born/woman":         unitDefinition{"ratio", "children per woman", 1},
+	// density and per person
+	"people per sq km": unitDefinition{"density", "people per sq m", 1e-6},
+	"kWh per person":   unitDefinition{"energy_per_person", "J per person", 3.6e6},
+	// counts and indexes
+	"people":     unitDefinition{"count", "people", 1},
+	"gini_index": unitDefinition{"index", "gini_index", 1},
+}
+
+// Returns the registry entry for units, accepting minor variations such as
+// repeated spaces.
+func unitsToCanonical(units string) (unitDefinition, error) {
+	units = strings.Join(strings.Fields(units), " ")
+	// exchange rates are local currency units per USD, eg EUR per USD, or
+	// the currency name where the page has no code
+	if endsWith(units, " per USD") {
+		currency := strings.TrimSuffix(units, " per USD")
+		if isCurrencyCode(currency) {
+			return unitDefinition{"exchange_rate", units, 1}, nil
+		}
+		code, exists := currencyCodeForName(currency, 0)
+		if exists {
+			return unitDefinition{"exchange_rate", code + " per USD", 1}, nil
+		}
+		return unitDefinition{}, UnknownUnitsErr
+	}
+	u, exists := unitRegistry[units]
+	// budgets in other currencies, eg EUR
+	if !exists && isCurrencyCode(units) {
+		return unitDefinition{"currency", units, 1}, nil
+	}
+	if !exists {
+		return u, UnknownUnitsErr
+	}
+	return u, nil
+}
+
+// Adds value_si and canonical_units to o if the units are in the registry.
+// Unknown units are left alone and can be found using UnknownUnits.
+func setCanonicalValue(o *orderedmap.OrderedMap, value float64, units string) {
+	u, err := unitsToCanonical(units)
+	if err != nil {
+		return
+	}
+	o.Set("value_si", value*u.scale)
+	o.Set("canonical_units", u.canonicalUnits)
+}
+
+// Returns the location and name of every units value in the parsed data
+// which is not in the units registry, eg "data.geography.area.total: acres"
+func UnknownUnits(o *orderedmap.OrderedMap) []string {
+	unknown := []string{}
+	unknownUnitsInValue(o, "", &unknown)
+	return unknown
+}
+
+func unknownUnitsInValue(v interface{}, location string, unknown *[]string) {
+	switch value := v.(type) {
+	case orderedmap.OrderedMap:
+		unknownUnitsInValue(&value, location, unknown)
+	case *orderedmap.OrderedMap:
+		for _, key := range value.Keys() {
+			child, _ := value.Get(key)
+			childLocation := key
+			if location != "" {
+				childLocation = location + "." + key
+			}
+			if key == "units" {
+				units, isString := child.(string)
+				if !isString || units == "" {
+					continue
+				}
+				_, err := unitsToCanonical(units)
+				if err != nil {
+					*unknown = append(*unknown, childLocation+": "+units)
+				}
+				continue
+			}
+			unknownUnitsInValue(child, childLocation, unknown)
+		}
+	case []*orderedmap.OrderedMap:
+		for _, child := range value {
+			unknownUnitsInValue(child, location, unknown)
+		}
+	case []interface{}:
+		for _, child := range value {
+			unknownUnitsInValue(child, location, unknown)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package country
+
+import (
+	"orderedmap"
+	"testing"
+)
+
+type CanonicalValueCase struct {
+	s                      string
+	expectedValueSi        float64
+	expectedCanonicalUnits string
+}
+
+var canonicalValueCases = []CanonicalValueCase{
+	// area
+	CanonicalValueCase{
+		s:                      "1,234 sq km",
+		expectedValueSi:        1234000000,
+		expectedCanonicalUnits: "sq m",
+	},
+	// length
+	CanonicalValueCase{
+		s:                      "12 nm",
+		expectedValueSi:        22224,
+		expectedCanonicalUnits: "m",
+	},
+	// energy with magnitude
+	CanonicalValueCase{
+		s:                      "2 million kWh",
+		expectedValueSi:        7.2e12,
+		expectedCanonicalUnits: "J",
+	},
+	// volume
+	CanonicalValueCase{
+		s:                      "3 cu km",
+		expectedValueSi:        3e9,
+		expectedCanonicalUnits: "cu m",
+	},
+	// percent
+	CanonicalValueCase{
+		s:                      "25%",
+		expectedValueSi:        0.25,
+		expectedCanonicalUnits: "fraction",
+	},
+	// currency is not converted
+	CanonicalValueCase{
+		s:                      "1.5 billion USD",
+		expectedValueSi:        1.5e9,
+		expectedCanonicalUnits: "USD",
+	},
+}
+
+func TestCanonicalValue(t *testing.T) {
+	for testIndex, c := range canonicalValueCases {
+		o, err := stringToNumberWithUnits(c.s)
+		if err != nil {
+			t.Error("canonical value error, testIndex: ", testIndex, err)
+			continue
+		}
+		v, exists := o.Get("value_si")
+		if !exists || v.(float64) != c.expectedValueSi {
+			t.Error("canonical value value_si, testIndex: ", testIndex, v)
+		}
+		u, _ := o.Get("canonical_units")
+		if u != c.expectedCanonicalUnits {
+			t.Error("canonical value canonical_units, testIndex: ", testIndex, u)
+		}
+	}
+}
+
+func TestCanonicalValueForGlobalRank(t *testing.T) {
+	o, err := stringToNumberWithGlobalRankAndDate("1.5 million bbl/day (2016 est.)\ncountry comparison to the world: 10", "bbl_per_day", "bbl/day")
+	if err != nil {
+		t.Error("canonical value for global rank error", err)
+	}
+	u, _ := o.Get("canonical_units")
+	if u != "cu m/s" {
+		t.Error("canonical value for global rank canonical_units", u)
+	}
+	// rates per 1000 people
+	o, err = stringToNumberWithGlobalRankAndDate("12.5 births/1,000 population (2017 est.)", "births_per_1000_population", "births/1,000 population")
+	if err != nil {
+		t.Error("canonical value for global rank error", err)
+	}
+	v, _ := o.Get("value_si")
+	if v != 0.0125 {
+		t.Error("canonical value for rate value_si", v)
+	}
+	// numbers without units have no canonical value
+	o, err = stringToNumberWithGlobalRankAndDate("5 (2016 est.)", "total", "")
+	if err != nil {
+		t.Error("canonical value for global rank error", err)
+	}
+	_, exists := o.Get("value_si")
+	if exists {
+		t.Error("canonical value for non-units key")
+	}
+}
+
+func TestUnknownUnits(t *testing.T) {
+	known := orderedmap.New()
+	known.Set("value", 1.0)
+	known.Set("units", "sq km")
+	unknown := orderedmap.New()
+	unknown.Set("value", 1.0)
+	unknown.Set("units", "acres")
+	area := orderedmap.New()
+	area.Set("total", known)
+	area.Set("land", []*orderedmap.OrderedMap{unknown})
+	o := orderedmap.New()
+	o.Set("area", area)
+	l := UnknownUnits(o)
+	if len(l) != 1 {
+		t.Error("unknown units length", l)
+		return
+	}
+	if l[0] != "area.land.units: acres" {
+		t.Error("unknown units value", l[0])
+	}
+}
+
+func TestExchangeRateUnits(t *testing.T) {
+	cases := map[string]bool{
+		"EUR per USD":              true,
+		"euros per USD":            true,
+		"XYZ per USD":              false,
+		"imaginary crowns per USD": false,
+	}
+	for units, isKnown := range cases {
+		_, err := unitsToCanonical(units)
+		if (err == nil) != isKnown {
+			t.Error("exchange rate units", units, err)
+		}
+	}
+}
+
+type NumberFieldUnitsCase struct {
+	field                  func(string) (interface{}, error)
+	s                      string
+	expectedCanonicalUnits string
+}
+
+// Fields parsed with stringToNumberWithGlobalRankAndDate, which have a
+// canonical value unless they count something
+var numberFieldUnitsCases = []NumberFieldUnitsCase{
+	NumberFieldUnitsCase{population, "326,625,791 (July 2017 est.)", ""},
+	NumberFieldUnitsCase{populationGrowthRate, "0.81% (2017 est.)", "fraction"},
+	NumberFieldUnitsCase{birthRate, "12.5 births/1,000 population (2017 est.)", "fraction"},
+	NumberFieldUnitsCase{deathRate, "8.2 deaths/1,000 population (2017 est.)", "fraction"},
+	NumberFieldUnitsCase{netMigrationRate, "3.9 migrant(s)/1,000 population (2017 est.)", "fraction"},
+	NumberFieldUnitsCase{maternalMortalityRate, "14 deaths/100,000 live births (2015 est.)", "fraction"},
+	NumberFieldUnitsCase{totalFertilityRate, "1.87 children born/woman (2017 est.)", "children per woman"},
+	NumberFieldUnitsCase{healthExpenditures, "17.1% of GDP (2014)", "fraction"},
+	NumberFieldUnitsCase{physiciansDensity, "2.55 physicians/1,000 population (2013)", "fraction"},
+	NumberFieldUnitsCase{hospitalBedDensity, "2.9 beds/1,000 population (2012)", "fraction"},
+	NumberFieldUnitsCase{obesityAdultPrevalenceRate, "36.2% (2016)", "fraction"},
+	NumberFieldUnitsCase{childrenUnderFiveYearsUnderweight, "0.5% (2012)", "fraction"},
+	NumberFieldUnitsCase{educationExpenditures, "4.9% of GDP (2014)", "fraction"},
+	NumberFieldUnitsCase{gdpOfficialExchangeRate, "$19.36 trillion (2017 est.)", "USD"},
+	NumberFieldUnitsCase{industrialProductionGrowthRate, "2% (2017 est.)", "fraction"},
+	NumberFieldUnitsCase{laborForceTotal, "160.4 million (2017 est.)", "people"},
+	NumberFieldUnitsCase{taxesAndOtherRevenues, "17% (of GDP) (2017 est.)", "fraction"},
+	NumberFieldUnitsCase{budgetSurplusOrDeficit, "-3.4% (of GDP) (2017 est.)", "fraction"},
+	NumberFieldUnitsCase{electricityTotalKwh, "4.079 trillion kWh (2016 est.)", "J"},
+	NumberFieldUnitsCase{electricityTotalKw, "1.074 billion kW (2016 est.)", "W"},
+	NumberFieldUnitsCase{electricityPercent, "63% of total installed capacity (2016 est.)", "fraction"},
+	NumberFieldUnitsCase{crudeOilBblPerDay, "9.352 million bbl/day (2017 est.)", "cu m/s"},
+	NumberFieldUnitsCase{crudeOilBbl, "36.52 billion bbl (1 January 2017 est.)", "cu m"},
+	NumberFieldUnitsCase{naturalGasCuM, "749.2 billion cu m (2017 est.)", "cu m"},
+	NumberFieldUnitsCase{carbonDioxideEmissions, "5.242 billion Mt (2017 est.)", "kg"},
+	NumberFieldUnitsCase{airportsTotal, "13,513 (2013)", ""},
+	NumberFieldUnitsCase{heliports, "5,287 (2013)", ""},
+}
+
+func TestNumberFieldUnits(t *testing.T) {
+	for testIndex, c := range numberFieldUnitsCases {
+		v, err := c.field(c.s)
+		if err != nil {
+			t.Error("number field error, testIndex: ", testIndex, err)
+			continue
+		}
+		o := v.(*orderedmap.OrderedMap)
+		u, exists := o.Get("canonical_units")
+		if c.expectedCanonicalUnits == "" && exists {
+			t.Error("number field unexpected canonical_units, testIndex: ", testIndex, u)
+		}
+		if c.expectedCanonicalUnits != "" && u != c.expectedCanonicalUnits {
+			t.Error("number field canonical_units, testIndex: ", testIndex, u)
+		}
+	}
+}
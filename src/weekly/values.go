@@ -0,0 +1,56 @@
+package weekly
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io/ioutil"
+	"orderedmap"
+	"strconv"
+)
+
+// Returns the string at the path of keys, or an empty string if there is no
+// string at that path
+func StringAtPath(v interface{}, keys ...string) string {
+	value, _ := ValueAtPath(v, keys...)
+	s, _ := value.(string)
+	return s
+}
+
+// Returns the keys of a map in parsed json, or an empty list if v is not a
+// map
+func MapKeys(v interface{}) []string {
+	switch o := v.(type) {
+	case *orderedmap.OrderedMap:
+		return o.Keys()
+	case orderedmap.OrderedMap:
+		return o.Keys()
+	}
+	return []string{}
+}
+
+// Returns the number in parsed json, or 0 if v is not a number
+func FloatValue(v interface{}) float64 {
+	f, _ := v.(float64)
+	return f
+}
+
+// Returns the number in parsed json as a csv value, or an empty string if v
+// is not a number
+func NumberString(v interface{}) string {
+	f, isFloat := v.(float64)
+	if !isFloat {
+		return ""
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Writes the rows to a csv file
+func WriteCsv(filelocation string, rows [][]string) error {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	err := w.WriteAll(rows)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filelocation, b.Bytes(), 0664)
+}
@@ -0,0 +1,56 @@
+package weekly
+
+import (
+	"io/ioutil"
+	"orderedmap"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestValues(t *testing.T) {
+	inner := orderedmap.New()
+	inner.Set("name", "Czechia")
+	inner.Set("count", 5.0)
+	o := orderedmap.New()
+	o.Set("data", inner)
+	if s := StringAtPath(o, "data", "name"); s != "Czechia" {
+		t.Error("StringAtPath", s)
+	}
+	if s := StringAtPath(o, "data", "count"); s != "" {
+		t.Error("StringAtPath not a string", s)
+	}
+	keys := MapKeys(inner)
+	if len(keys) != 2 || keys[0] != "name" {
+		t.Error("MapKeys", keys)
+	}
+	if len(MapKeys("not a map")) != 0 {
+		t.Error("MapKeys not a map")
+	}
+	count, _ := ValueAtPath(o, "data", "count")
+	if FloatValue(count) != 5 || NumberString(count) != "5" {
+		t.Error("FloatValue and NumberString", count)
+	}
+	if NumberString("5") != "" {
+		t.Error("NumberString not a number")
+	}
+}
+
+func TestWriteCsv(t *testing.T) {
+	root, err := ioutil.TempDir("", "weekly")
+	if err != nil {
+		t.Error("write csv temp dir", err)
+		return
+	}
+	defer os.RemoveAll(root)
+	filelocation := path.Join(root, "rows.csv")
+	err = WriteCsv(filelocation, [][]string{[]string{"gec", "name"}, []string{"ez", "Czechia, the"}})
+	if err != nil {
+		t.Error("write csv error", err)
+		return
+	}
+	b, _ := ioutil.ReadFile(filelocation)
+	if string(b) != "gec,name\nez,\"Czechia, the\"\n" {
+		t.Error("write csv content", string(b))
+	}
+}
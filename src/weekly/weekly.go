@@ -0,0 +1,161 @@
+package weekly
+
+import (
+	"country"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"orderedmap"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+var NoCountriesErr = errors.New("No countries in weekly file")
+
+const filenameSuffix = "_factbook.json"
+
+// File is a weekly json file created by create_weekly_json_files.go
+type File struct {
+	Date         time.Time
+	Filelocation string
+}
+
+type ByDate []File
+
+func (a ByDate) Len() int           { return len(a) }
+func (a ByDate) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a ByDate) Less(i, j int) bool { return a[i].Date.Before(a[j].Date) }
+
+// Country is a single country from a weekly file.
+// Key is the key used in the weekly file, which depends on the country_key
+// config value, and Gec is the code from the factbook filename.
+type Country struct {
+	Key    string
+	Gec    string
+	Name   string
+	Region string
+	Json   interface{}
+}
+
+// Returns the weekly files in root with the earliest first.
+// Files are named YYYY-MM-DD_factbook.json
+func Files(root string) ([]File, error) {
+	files := []File{}
+	fileinfos, err := ioutil.ReadDir(root)
+	if err != nil {
+		return files, err
+	}
+	for _, fileinfo := range fileinfos {
+		name := fileinfo.Name()
+		if fileinfo.IsDir() || !strings.HasSuffix(name, filenameSuffix) {
+			continue
+		}
+		d, err := time.Parse("2006-01-02", strings.TrimSuffix(name, filenameSuffix))
+		if err != nil {
+			continue
+		}
+		f := File{
+			Date:         d,
+			Filelocation: path.Join(root, name),
+		}
+		files = append(files, f)
+	}
+	sort.Sort(ByDate(files))
+	return files, nil
+}
+
+// Reads a weekly file
+func Load(filelocation string) (*orderedmap.OrderedMap, error) {
+	o := orderedmap.New()
+	b, err := ioutil.ReadFile(filelocation)
+	if err != nil {
+		return o, err
+	}
+	err = json.Unmarshal(b, o)
+	return o, err
+}
+
+// Returns the countries in a weekly file in the order they appear.
+// The GEC code is read from the metadata, or found from the country name for
+// files parsed before the codes were added to the metadata.
+func Countries(o *orderedmap.OrderedMap) ([]Country, error) {
+	return countriesForKey(o, "countries")
+}
+
+// Returns the world, the EU and the oceans in a weekly file.
+// Files created before aggregates were added have none.
+func Aggregates(o *orderedmap.OrderedMap) ([]Country, error) {
+	return countriesForKey(o, "aggregates")
+}
+
+func countriesForKey(o *orderedmap.OrderedMap, countriesKey string) ([]Country, error) {
+	countries := []Country{}
+	countriesInterface, exists := o.Get(countriesKey)
+	if !exists {
+		return countries, NoCountriesErr
+	}
+	var countriesMap *orderedmap.OrderedMap
+	switch m := countriesInterface.(type) {
+	case *orderedmap.OrderedMap:
+		countriesMap = m
+	case orderedmap.OrderedMap:
+		countriesMap = &m
+	default:
+		return countries, NoCountriesErr
+	}
+	for _, key := range countriesMap.Keys() {
+		cj, _ := countriesMap.Get(key)
+		c := Country{
+			Key:  key,
+			Json: cj,
+		}
+		name, _ := ValueAtPath(cj, "data", "name")
+		c.Name, _ = name.(string)
+		region, _ := ValueAtPath(cj, "metadata", "region")
+		c.Region, _ = region.(string)
+		gec, _ := ValueAtPath(cj, "metadata", "codes", "gec")
+		c.Gec, _ = gec.(string)
+		if c.Gec == "" {
+			codes, exists := country.CodesForCountryName(c.Name)
+			if exists {
+				c.Gec = codes.Gec
+			}
+		}
+		countries = append(countries, c)
+	}
+	return countries, nil
+}
+
+// Returns the value for a series of nested keys in parsed json,
+// eg data, transnational_issues, disputes
+func ValueAtPath(v interface{}, keys ...string) (interface{}, bool) {
+	for _, key := range keys {
+		var exists bool
+		switch o := v.(type) {
+		case *orderedmap.OrderedMap:
+			v, exists = o.Get(key)
+		case orderedmap.OrderedMap:
+			v, exists = o.Get(key)
+		}
+		if !exists {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// Returns the list at the path of keys, or an empty list if there is no
+// list at that path
+func ListAtPath(v interface{}, keys ...string) []interface{} {
+	l, exists := ValueAtPath(v, keys...)
+	if !exists {
+		return []interface{}{}
+	}
+	list, isList := l.([]interface{})
+	if !isList {
+		return []interface{}{}
+	}
+	return list
+}
@@ -0,0 +1,92 @@
+package weekly
+
+import (
+	"io/ioutil"
+	"orderedmap"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestFiles(t *testing.T) {
+	root, err := ioutil.TempDir("", "weekly")
+	if err != nil {
+		t.Error("weekly files temp dir", err)
+		return
+	}
+	defer os.RemoveAll(root)
+	names := []string{"2017-01-09_factbook.json", "2017-01-02_factbook.json", "notes.txt"}
+	for _, name := range names {
+		ioutil.WriteFile(path.Join(root, name), []byte("{}"), 0664)
+	}
+	files, err := Files(root)
+	if err != nil {
+		t.Error("weekly files error", err)
+	}
+	if len(files) != 2 {
+		t.Error("weekly files length", len(files))
+		return
+	}
+	if files[0].Date.Format("2006-01-02") != "2017-01-02" {
+		t.Error("weekly files order", files[0].Date)
+	}
+}
+
+func TestCountries(t *testing.T) {
+	root, err := ioutil.TempDir("", "weekly")
+	if err != nil {
+		t.Error("weekly countries temp dir", err)
+		return
+	}
+	defer os.RemoveAll(root)
+	content := `{"countries": {
+		"czechia": {"data": {"name": "Czechia"}, "metadata": {"codes": {"gec": "ez"}, "region": "Europe"}},
+		"swaziland": {"data": {"name": "Swaziland", "geography": {"land_boundaries": {"border_countries": [{"country": "Mozambique"}]}}}}
+	},
+	"aggregates": {
+		"world": {"data": {"name": "World"}, "metadata": {"codes": {"gec": "xx"}}}
+	}}`
+	filelocation := path.Join(root, "2017-01-02_factbook.json")
+	ioutil.WriteFile(filelocation, []byte(content), 0664)
+	o, err := Load(filelocation)
+	if err != nil {
+		t.Error("weekly countries load error", err)
+		return
+	}
+	countries, err := Countries(o)
+	if err != nil {
+		t.Error("weekly countries error", err)
+		return
+	}
+	if len(countries) != 2 {
+		t.Error("weekly countries length", len(countries))
+		return
+	}
+	// gec from metadata
+	if countries[0].Gec != "ez" {
+		t.Error("weekly countries gec from metadata", countries[0].Gec)
+	}
+	if countries[0].Region != "Europe" {
+		t.Error("weekly countries region", countries[0].Region)
+	}
+	// gec from name for older files
+	if countries[1].Gec != "wz" {
+		t.Error("weekly countries gec from name", countries[1].Gec)
+	}
+	aggregates, err := Aggregates(o)
+	if err != nil || len(aggregates) != 1 || aggregates[0].Gec != "xx" {
+		t.Error("weekly aggregates", aggregates, err)
+	}
+	borders := ListAtPath(countries[1].Json, "data", "geography", "land_boundaries", "border_countries")
+	if len(borders) != 1 {
+		t.Error("weekly countries list at path", borders)
+	}
+	_, exists := ValueAtPath(countries[1].Json, "data", "economy")
+	if exists {
+		t.Error("weekly countries value at missing path")
+	}
+	_, exists = ValueAtPath(orderedmap.New(), "data")
+	if exists {
+		t.Error("weekly countries value in empty map")
+	}
+}